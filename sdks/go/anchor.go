@@ -0,0 +1,148 @@
+package agentmesh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AnchorSink publishes a Merkle root snapshot somewhere external to the
+// audit log itself, so the log's integrity can later be checked against
+// a root nobody holding just the log could have forged.
+type AnchorSink interface {
+	PublishRoot(root string, size int, ts time.Time) error
+}
+
+// anchorRecord is the most recently published root, checked by Verify.
+type anchorRecord struct {
+	root string
+	size int
+}
+
+// PublishAnchor computes the current Merkle root and publishes it via
+// sink immediately, recording it as the log's last anchor so later
+// Verify calls check the entries it covers against it. StartAnchoring
+// calls this on every tick.
+func (al *AuditLogger) PublishAnchor(sink AnchorSink) error {
+	root, size := al.Root()
+	if size == 0 {
+		return fmt.Errorf("cannot anchor an empty audit log")
+	}
+
+	ts := time.Now().UTC()
+	if err := sink.PublishRoot(root, size, ts); err != nil {
+		return fmt.Errorf("publishing anchor: %w", err)
+	}
+
+	al.mu.Lock()
+	al.lastAnchor = &anchorRecord{root: root, size: size}
+	al.mu.Unlock()
+	return nil
+}
+
+// LastAnchor returns the most recently published anchor, if any.
+func (al *AuditLogger) LastAnchor() (root string, size int, ok bool) {
+	al.mu.RLock()
+	defer al.mu.RUnlock()
+	if al.lastAnchor == nil {
+		return "", 0, false
+	}
+	return al.lastAnchor.root, al.lastAnchor.size, true
+}
+
+// StartAnchoring starts a background goroutine that calls PublishAnchor
+// every interval. A failed publish is simply retried on the next tick
+// rather than surfacing an error, since there is no caller left to
+// receive one. The returned stop func halts the goroutine; callers must
+// call it to avoid leaking it.
+func (al *AuditLogger) StartAnchoring(interval time.Duration, sink AnchorSink) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = al.PublishAnchor(sink)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// FileAnchorSink appends each published root as a line, in
+// "timestamp root size" form, to an append-only file.
+type FileAnchorSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileAnchorSink creates a FileAnchorSink writing to path. The file
+// is not touched here; PublishRoot creates it if it does not already
+// exist.
+func NewFileAnchorSink(path string) *FileAnchorSink {
+	return &FileAnchorSink{path: path}
+}
+
+// PublishRoot implements AnchorSink.
+func (s *FileAnchorSink) PublishRoot(root string, size int, ts time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening anchor file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s %s %d\n", ts.Format(time.RFC3339Nano), root, size); err != nil {
+		return fmt.Errorf("writing anchor: %w", err)
+	}
+	return nil
+}
+
+// HTTPAnchorSink publishes each root as a JSON POST to Endpoint, for
+// anchoring into an external service such as a timestamping authority
+// or a public ledger.
+type HTTPAnchorSink struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewHTTPAnchorSink creates an HTTPAnchorSink posting to endpoint using
+// http.DefaultClient.
+func NewHTTPAnchorSink(endpoint string) *HTTPAnchorSink {
+	return &HTTPAnchorSink{Endpoint: endpoint}
+}
+
+// PublishRoot implements AnchorSink.
+func (s *HTTPAnchorSink) PublishRoot(root string, size int, ts time.Time) error {
+	body, err := json.Marshal(struct {
+		Root      string    `json:"root"`
+		Size      int       `json:"size"`
+		Timestamp time.Time `json:"timestamp"`
+	}{root, size, ts})
+	if err != nil {
+		return fmt.Errorf("encoding anchor: %w", err)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Post(s.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("publishing anchor: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("anchor endpoint %s returned status %d", s.Endpoint, resp.StatusCode)
+	}
+	return nil
+}
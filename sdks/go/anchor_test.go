@@ -0,0 +1,159 @@
+package agentmesh
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeAnchorSink struct {
+	published []string
+}
+
+func (s *fakeAnchorSink) PublishRoot(root string, size int, ts time.Time) error {
+	s.published = append(s.published, root)
+	return nil
+}
+
+func TestPublishAnchorRejectsEmptyLog(t *testing.T) {
+	al := NewAuditLogger()
+	if err := al.PublishAnchor(&fakeAnchorSink{}); err == nil {
+		t.Error("expected error anchoring an empty log")
+	}
+}
+
+func TestPublishAnchorRecordsLastAnchor(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	sink := &fakeAnchorSink{}
+
+	if err := al.PublishAnchor(sink); err != nil {
+		t.Fatalf("PublishAnchor: %v", err)
+	}
+	root, size, ok := al.LastAnchor()
+	if !ok || size != 1 {
+		t.Fatalf("LastAnchor = (%q, %d, %v), want a recorded anchor of size 1", root, size, ok)
+	}
+	if len(sink.published) != 1 {
+		t.Fatalf("sink received %d publishes, want 1", len(sink.published))
+	}
+}
+
+func TestVerifyDetectsTamperingAfterAnchoring(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	al.Log("a", "y", Deny)
+
+	if err := al.PublishAnchor(&fakeAnchorSink{}); err != nil {
+		t.Fatalf("PublishAnchor: %v", err)
+	}
+
+	// Tamper with an entry and patch the chain links so verifyChain
+	// alone would still accept it.
+	al.entries[0].AgentID = "tampered"
+	al.entries[0].Hash = computeHash(al.entries[0])
+	al.entries[1].PreviousHash = al.entries[0].Hash
+	al.entries[1].Hash = computeHash(al.entries[1])
+
+	if al.Verify() {
+		t.Error("Verify should detect tampering against the anchored root even when the chain links were repaired")
+	}
+}
+
+func TestVerifyStillPassesForUnanchoredAppends(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	if err := al.PublishAnchor(&fakeAnchorSink{}); err != nil {
+		t.Fatalf("PublishAnchor: %v", err)
+	}
+	al.Log("a", "y", Deny) // appended after anchoring, never tampered
+
+	if !al.Verify() {
+		t.Error("appending new entries after anchoring should not break Verify")
+	}
+}
+
+func TestStartAnchoringPublishesPeriodically(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	sink := &fakeAnchorSink{}
+
+	stop := al.StartAnchoring(10*time.Millisecond, sink)
+	defer stop()
+
+	deadline := time.After(time.Second)
+	for {
+		if _, _, ok := al.LastAnchor(); ok {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for background anchoring to publish")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestFileAnchorSinkAppendsLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anchors.log")
+	sink := NewFileAnchorSink(path)
+
+	if err := sink.PublishRoot("root-1", 1, time.Now().UTC()); err != nil {
+		t.Fatalf("PublishRoot: %v", err)
+	}
+	if err := sink.PublishRoot("root-2", 2, time.Now().UTC()); err != nil {
+		t.Fatalf("PublishRoot: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading anchor file: %v", err)
+	}
+	if got := string(data); !contains(got, "root-1") || !contains(got, "root-2") {
+		t.Errorf("anchor file = %q, want both published roots", got)
+	}
+}
+
+func TestHTTPAnchorSinkPostsJSON(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPAnchorSink(server.URL)
+	if err := sink.PublishRoot("abc123", 3, time.Now().UTC()); err != nil {
+		t.Fatalf("PublishRoot: %v", err)
+	}
+	if received["root"] != "abc123" {
+		t.Errorf("received root = %v, want abc123", received["root"])
+	}
+}
+
+func TestHTTPAnchorSinkReturnsErrorOnFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPAnchorSink(server.URL)
+	if err := sink.PublishRoot("abc123", 1, time.Now().UTC()); err == nil {
+		t.Error("expected error when the anchor endpoint returns a failure status")
+	}
+}
+
+func contains(haystack, needle string) bool {
+	return len(haystack) >= len(needle) && (func() bool {
+		for i := 0; i+len(needle) <= len(haystack); i++ {
+			if haystack[i:i+len(needle)] == needle {
+				return true
+			}
+		}
+		return false
+	})()
+}
@@ -3,33 +3,62 @@ package agentmesh
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 // AuditEntry represents a single immutable audit record.
 type AuditEntry struct {
-	Timestamp    time.Time      `json:"timestamp"`
-	AgentID      string         `json:"agent_id"`
-	Action       string         `json:"action"`
-	Decision     PolicyDecision `json:"decision"`
-	Hash         string         `json:"hash"`
-	PreviousHash string         `json:"previous_hash"`
+	Timestamp    time.Time         `json:"timestamp"`
+	AgentID      string            `json:"agent_id"`
+	Action       string            `json:"action"`
+	Decision     PolicyDecision    `json:"decision"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Hash         string            `json:"hash"`
+	PreviousHash string            `json:"previous_hash"`
 }
 
-// AuditLogger maintains an append-only hash-chained audit log.
+// AuditLogger maintains an append-only hash-chained audit log, plus any
+// chains imported from peers via ImportChain. Entries are indexed by
+// AgentID and Action, and the entry slice is itself time-ordered, so
+// GetEntries with an AgentID, Action or time-range filter scans only
+// the entries that index holds instead of the whole chain. Watch
+// subscribers are not indexed: each logged entry is tested against
+// every subscriber's filter.
 type AuditLogger struct {
-	mu      sync.Mutex
-	entries []*AuditEntry
+	mu         sync.RWMutex
+	entries    []*AuditEntry
+	peerChains map[string][]*AuditEntry
+
+	byAgent  map[string][]int
+	byAction map[string][]int
+	watchers []*auditWatcher
+
+	lastAnchor *anchorRecord
 }
 
 // NewAuditLogger creates an empty AuditLogger.
 func NewAuditLogger() *AuditLogger {
-	return &AuditLogger{}
+	return &AuditLogger{
+		peerChains: make(map[string][]*AuditEntry),
+		byAgent:    make(map[string][]int),
+		byAction:   make(map[string][]int),
+	}
 }
 
 // Log appends a new entry to the audit chain.
 func (al *AuditLogger) Log(agentID, action string, decision PolicyDecision) *AuditEntry {
+	return al.LogWithMetadata(agentID, action, decision, nil)
+}
+
+// LogWithMetadata appends a new entry like Log, but attaches arbitrary
+// key/value metadata (e.g. a recovered panic's stack trace) to the entry.
+// Metadata is folded into the entry's hash, so it is covered by Verify
+// like every other field.
+func (al *AuditLogger) LogWithMetadata(agentID, action string, decision PolicyDecision, metadata map[string]string) *AuditEntry {
 	al.mu.Lock()
 	defer al.mu.Unlock()
 
@@ -43,61 +72,223 @@ func (al *AuditLogger) Log(agentID, action string, decision PolicyDecision) *Aud
 		AgentID:      agentID,
 		Action:       action,
 		Decision:     decision,
+		Metadata:     metadata,
 		PreviousHash: prevHash,
 	}
 	entry.Hash = computeHash(entry)
+
+	idx := len(al.entries)
 	al.entries = append(al.entries, entry)
+	al.byAgent[agentID] = append(al.byAgent[agentID], idx)
+	al.byAction[action] = append(al.byAction[action], idx)
+	al.notifyWatchers(entry)
+
 	return entry
 }
 
-// Verify checks the integrity of the entire Merkle chain.
+// Verify checks the integrity of the local chain and of every chain
+// imported from a peer, independently of one another. When a root has
+// been published via StartAnchoring or PublishAnchor, Verify also
+// recomputes the Merkle tree over the anchored prefix of entries and
+// compares it against that root, so tampering that rewrites both an
+// entry and the PreviousHash links after it is still caught even though
+// verifyChain alone would accept the rewritten chain.
 func (al *AuditLogger) Verify() bool {
+	al.mu.RLock()
+	defer al.mu.RUnlock()
+
+	if err := verifyChain(al.entries); err != nil {
+		return false
+	}
+	for _, chain := range al.peerChains {
+		if err := verifyChain(chain); err != nil {
+			return false
+		}
+	}
+
+	if al.lastAnchor != nil {
+		if al.lastAnchor.size > len(al.entries) {
+			return false
+		}
+		levels := merkleLevels(al.leafHashesLockedUpTo(al.lastAnchor.size))
+		root := hex.EncodeToString(levels[len(levels)-1][0])
+		if root != al.lastAnchor.root {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportChain verifies the hash-chain integrity of entries received from
+// peerID and stores them under that peer's namespace. Imported chains
+// never merge into the local sequence (and so are not covered by the
+// AgentID/Action/time indexes), but Verify validates them alongside it,
+// and PeerEntries retrieves them for inspection or re-export.
+func (al *AuditLogger) ImportChain(peerID string, entries []*AuditEntry) error {
+	if err := verifyChain(entries); err != nil {
+		return fmt.Errorf("importing chain from %q: %w", peerID, err)
+	}
+
 	al.mu.Lock()
 	defer al.mu.Unlock()
+	al.peerChains[peerID] = entries
+	return nil
+}
 
-	for i, entry := range al.entries {
-		expected := computeHash(entry)
-		if entry.Hash != expected {
-			return false
+// PeerEntries returns the most recently imported chain for peerID, or
+// nil if no chain has been imported from that peer.
+func (al *AuditLogger) PeerEntries(peerID string) []*AuditEntry {
+	al.mu.RLock()
+	defer al.mu.RUnlock()
+	return al.peerChains[peerID]
+}
+
+func verifyChain(entries []*AuditEntry) error {
+	for i, entry := range entries {
+		if entry.Hash != computeHash(entry) {
+			return fmt.Errorf("entry %d: hash mismatch", i)
 		}
 		if i == 0 {
 			if entry.PreviousHash != "" {
-				return false
-			}
-		} else {
-			if entry.PreviousHash != al.entries[i-1].Hash {
-				return false
+				return fmt.Errorf("entry 0: expected empty previous hash")
 			}
+			continue
+		}
+		if entry.PreviousHash != entries[i-1].Hash {
+			return fmt.Errorf("entry %d: previous hash mismatch", i)
 		}
 	}
-	return true
+	return nil
 }
 
-// GetEntries returns entries matching the given filter.
+// GetEntries returns entries matching the given filter, scanning only
+// the AgentID, Action or time-range index that candidateIndices picks
+// for the filter; a filter with none of those set scans the whole chain.
 func (al *AuditLogger) GetEntries(filter AuditFilter) []*AuditEntry {
-	al.mu.Lock()
-	defer al.mu.Unlock()
+	al.mu.RLock()
+	defer al.mu.RUnlock()
 
 	var result []*AuditEntry
-	for _, e := range al.entries {
-		if filter.AgentID != "" && e.AgentID != filter.AgentID {
-			continue
+	for _, idx := range al.candidateIndices(filter) {
+		e := al.entries[idx]
+		if matchesFilter(e, filter) {
+			result = append(result, e)
 		}
-		if filter.Action != "" && e.Action != filter.Action {
-			continue
+	}
+	return result
+}
+
+// candidateIndices picks a secondary index for filter in fixed order,
+// without comparing index sizes: the AgentID index if AgentID is set,
+// else the Action index if Action is set, else a binary search over the
+// time-ordered entry slice if a time bound is set; any other filter
+// (including one on Decision only) scans everything.
+func (al *AuditLogger) candidateIndices(filter AuditFilter) []int {
+	switch {
+	case filter.AgentID != "":
+		return al.byAgent[filter.AgentID]
+	case filter.Action != "":
+		return al.byAction[filter.Action]
+	case filter.StartTime != nil || filter.EndTime != nil:
+		return al.timeRangeIndices(filter.StartTime, filter.EndTime)
+	default:
+		all := make([]int, len(al.entries))
+		for i := range al.entries {
+			all[i] = i
 		}
-		if filter.Decision != nil && e.Decision != *filter.Decision {
-			continue
+		return all
+	}
+}
+
+// timeRangeIndices binary-searches the time-ordered entry slice for the
+// span [start, end], since Log always appends with a non-decreasing
+// timestamp.
+func (al *AuditLogger) timeRangeIndices(start, end *time.Time) []int {
+	lo := 0
+	if start != nil {
+		lo = sort.Search(len(al.entries), func(i int) bool {
+			return !al.entries[i].Timestamp.Before(*start)
+		})
+	}
+	hi := len(al.entries)
+	if end != nil {
+		hi = sort.Search(len(al.entries), func(i int) bool {
+			return al.entries[i].Timestamp.After(*end)
+		})
+	}
+	if lo >= hi {
+		return nil
+	}
+	indices := make([]int, hi-lo)
+	for i := range indices {
+		indices[i] = lo + i
+	}
+	return indices
+}
+
+func matchesFilter(e *AuditEntry, filter AuditFilter) bool {
+	if filter.AgentID != "" && e.AgentID != filter.AgentID {
+		return false
+	}
+	if filter.Action != "" && e.Action != filter.Action {
+		return false
+	}
+	if filter.Decision != nil && e.Decision != *filter.Decision {
+		return false
+	}
+	if filter.StartTime != nil && e.Timestamp.Before(*filter.StartTime) {
+		return false
+	}
+	if filter.EndTime != nil && e.Timestamp.After(*filter.EndTime) {
+		return false
+	}
+	return true
+}
+
+// auditWatcher is a single Watch subscription.
+type auditWatcher struct {
+	filter AuditFilter
+	ch     chan *AuditEntry
+}
+
+// Watch subscribes to entries matching filter as they are logged,
+// without polling GetEntries. The returned cancel func unregisters the
+// subscription and closes the channel; callers must call it to avoid
+// leaking the subscription.
+func (al *AuditLogger) Watch(filter AuditFilter) (<-chan *AuditEntry, func()) {
+	w := &auditWatcher{filter: filter, ch: make(chan *AuditEntry, 16)}
+
+	al.mu.Lock()
+	al.watchers = append(al.watchers, w)
+	al.mu.Unlock()
+
+	cancel := func() {
+		al.mu.Lock()
+		defer al.mu.Unlock()
+		for i, existing := range al.watchers {
+			if existing == w {
+				al.watchers = append(al.watchers[:i], al.watchers[i+1:]...)
+				close(w.ch)
+				break
+			}
 		}
-		if filter.StartTime != nil && e.Timestamp.Before(*filter.StartTime) {
+	}
+	return w.ch, cancel
+}
+
+// notifyWatchers delivers entry to every watcher whose filter matches
+// it. Callers must hold al.mu for writing. A watcher whose channel is
+// full has its entry dropped rather than blocking Log.
+func (al *AuditLogger) notifyWatchers(entry *AuditEntry) {
+	for _, w := range al.watchers {
+		if !matchesFilter(entry, w.filter) {
 			continue
 		}
-		if filter.EndTime != nil && e.Timestamp.After(*filter.EndTime) {
-			continue
+		select {
+		case w.ch <- entry:
+		default:
 		}
-		result = append(result, e)
 	}
-	return result
 }
 
 func computeHash(e *AuditEntry) string {
@@ -105,7 +296,30 @@ func computeHash(e *AuditEntry) string {
 		e.AgentID + "|" +
 		e.Action + "|" +
 		string(e.Decision) + "|" +
+		metadataKey(e.Metadata) + "|" +
 		e.PreviousHash
 	h := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(h[:])
 }
+
+// metadataKey deterministically serialises metadata for hashing, sorting
+// keys so map iteration order never changes a previously computed hash.
+func metadataKey(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(metadata[k])
+		b.WriteByte(';')
+	}
+	return b.String()
+}
@@ -2,6 +2,7 @@ package agentmesh
 
 import (
 	"testing"
+	"time"
 )
 
 func TestAuditLogAndVerify(t *testing.T) {
@@ -57,6 +58,99 @@ func TestAuditEmptyVerify(t *testing.T) {
 	}
 }
 
+func TestAuditLogWithMetadata(t *testing.T) {
+	al := NewAuditLogger()
+	e := al.LogWithMetadata("agent-1", "policy.panic", Deny, map[string]string{"panic": "boom"})
+
+	if e.Metadata["panic"] != "boom" {
+		t.Errorf("metadata[panic] = %q, want boom", e.Metadata["panic"])
+	}
+	if !al.Verify() {
+		t.Error("chain with metadata should still verify")
+	}
+
+	e.Metadata["panic"] = "tampered"
+	if al.Verify() {
+		t.Error("tampering with metadata should invalidate the chain")
+	}
+}
+
+func TestAuditImportChainRejectsTamperedEntries(t *testing.T) {
+	source := NewAuditLogger()
+	source.Log("peer-agent", "x", Allow)
+	entries := source.GetEntries(AuditFilter{})
+	entries[0].AgentID = "tampered"
+
+	al := NewAuditLogger()
+	if err := al.ImportChain("peer-1", entries); err == nil {
+		t.Error("expected ImportChain to reject a tampered chain")
+	}
+}
+
+func TestAuditImportChainIsIndependentOfLocalChain(t *testing.T) {
+	source := NewAuditLogger()
+	source.Log("peer-agent", "x", Allow)
+
+	al := NewAuditLogger()
+	al.Log("local-agent", "y", Deny)
+	if err := al.ImportChain("peer-1", source.GetEntries(AuditFilter{})); err != nil {
+		t.Fatalf("ImportChain: %v", err)
+	}
+
+	if !al.Verify() {
+		t.Error("local and imported chains should both verify independently")
+	}
+	if len(al.GetEntries(AuditFilter{})) != 1 {
+		t.Error("importing a peer chain should not merge into the local sequence")
+	}
+}
+
+func TestAuditGetEntriesUsesTimeRangeIndex(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	mid := time.Now().UTC()
+	al.Log("a", "y", Allow)
+
+	entries := al.GetEntries(AuditFilter{StartTime: &mid})
+	if len(entries) != 1 || entries[0].Action != "y" {
+		t.Fatalf("time-filtered entries = %+v, want just the entry after mid", entries)
+	}
+}
+
+func TestAuditWatchReceivesMatchingEntries(t *testing.T) {
+	al := NewAuditLogger()
+	ch, cancel := al.Watch(AuditFilter{AgentID: "watched-agent"})
+	defer cancel()
+
+	al.Log("other-agent", "noise", Allow)
+	al.Log("watched-agent", "data.read", Allow)
+
+	select {
+	case e := <-ch:
+		if e.AgentID != "watched-agent" {
+			t.Errorf("watch delivered agent %q, want watched-agent", e.AgentID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for watched entry")
+	}
+
+	select {
+	case e := <-ch:
+		t.Fatalf("unexpected second delivery: %+v", e)
+	default:
+	}
+}
+
+func TestAuditWatchCancelClosesChannel(t *testing.T) {
+	al := NewAuditLogger()
+	ch, cancel := al.Watch(AuditFilter{})
+	cancel()
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after cancel")
+	}
+}
+
 func TestAuditHashesAreUnique(t *testing.T) {
 	al := NewAuditLogger()
 	e1 := al.Log("a", "action1", Allow)
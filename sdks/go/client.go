@@ -27,6 +27,11 @@ func NewClient(agentID string, opts ...Option) (*AgentMeshClient, error) {
 		trustCfg = *o.trustConfig
 	}
 
+	trustStrategy := o.trustStrategy
+	if trustStrategy == nil {
+		trustStrategy = LinearStrategy{}
+	}
+
 	var rules []PolicyRule
 	if o.policyRules != nil {
 		rules = o.policyRules
@@ -34,7 +39,7 @@ func NewClient(agentID string, opts ...Option) (*AgentMeshClient, error) {
 
 	return &AgentMeshClient{
 		Identity: identity,
-		Trust:    NewTrustManager(trustCfg),
+		Trust:    NewTrustManagerWithStrategy(trustCfg, trustStrategy),
 		Policy:   NewPolicyEngine(rules),
 		Audit:    NewAuditLogger(),
 	}, nil
@@ -0,0 +1,312 @@
+package agentmesh
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expression is the compiled form of a PolicyRule's Expression field: a
+// small CEL-style boolean language supporting comparison operators
+// (==, !=, <, >, in, matches), boolean composition (and/or/not), and
+// dotted paths (request.user.role) resolved against the context map
+// passed to Evaluate.
+type expression interface {
+	eval(ctx map[string]interface{}) (interface{}, error)
+}
+
+// ParseExpression compiles a policy expression, such as
+// `request.user.role in ["admin","auditor"]`, into an expression tree
+// that PolicyEngine.Evaluate can run directly without re-parsing.
+func ParseExpression(src string) (expression, error) {
+	p := &exprParser{tokens: tokenizeExpr(src)}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+var exprTokenPattern = regexp.MustCompile(`"[^"]*"|==|!=|<=|>=|[()\[\],<>]|[A-Za-z0-9_.]+`)
+
+func tokenizeExpr(src string) []string {
+	return exprTokenPattern.FindAllString(src, -1)
+}
+
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// parseOr parses the lowest-precedence level: `and`-expressions joined
+// by `or`.
+func (p *exprParser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []expression{left}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &boolExpr{op: "or", children: children}, nil
+}
+
+func (p *exprParser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []expression{left}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &boolExpr{op: "and", children: children}, nil
+}
+
+func (p *exprParser) parseUnary() (expression, error) {
+	switch p.peek() {
+	case "not":
+		p.next()
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{child: child}, nil
+	case "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected closing paren")
+		}
+		return inner, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *exprParser) parseComparison() (expression, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek() {
+	case "==", "!=", "<", ">", "in", "matches":
+		op := p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &compareExpr{left: left, right: right, op: op}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseOperand() (expression, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tok == "[":
+		var items []expression
+		for p.peek() != "]" {
+			if p.peek() == "" {
+				return nil, fmt.Errorf("unterminated list literal")
+			}
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if p.peek() == "," {
+				p.next()
+			}
+		}
+		p.next() // consume "]"
+		return &listExpr{items: items}, nil
+	case strings.HasPrefix(tok, `"`):
+		return &literalExpr{value: strings.Trim(tok, `"`)}, nil
+	case tok == "true" || tok == "false":
+		return &literalExpr{value: tok == "true"}, nil
+	default:
+		if f, err := strconv.ParseFloat(tok, 64); err == nil {
+			return &literalExpr{value: f}, nil
+		}
+		return &pathExpr{path: strings.Split(tok, ".")}, nil
+	}
+}
+
+// pathExpr resolves a dotted path (e.g. "request.user.role") by walking
+// nested maps in the evaluation context. It resolves to nil, not an
+// error, when an intermediate key is absent or not a map.
+type pathExpr struct{ path []string }
+
+func (e *pathExpr) eval(ctx map[string]interface{}) (interface{}, error) {
+	var cur interface{} = ctx
+	for _, part := range e.path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		cur, ok = m[part]
+		if !ok {
+			return nil, nil
+		}
+	}
+	return cur, nil
+}
+
+type literalExpr struct{ value interface{} }
+
+func (e *literalExpr) eval(map[string]interface{}) (interface{}, error) { return e.value, nil }
+
+type listExpr struct{ items []expression }
+
+func (e *listExpr) eval(ctx map[string]interface{}) (interface{}, error) {
+	values := make([]interface{}, len(e.items))
+	for i, item := range e.items {
+		v, err := item.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+type compareExpr struct {
+	left, right expression
+	op          string
+}
+
+func (e *compareExpr) eval(ctx map[string]interface{}) (interface{}, error) {
+	lv, err := e.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rv, err := e.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "==":
+		return valuesEqual(lv, rv), nil
+	case "!=":
+		return !valuesEqual(lv, rv), nil
+	case "<":
+		return compareNumbers(lv, rv, func(a, b float64) bool { return a < b }), nil
+	case ">":
+		return compareNumbers(lv, rv, func(a, b float64) bool { return a > b }), nil
+	case "in":
+		items, ok := rv.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, item := range items {
+			if valuesEqual(lv, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "matches":
+		pattern, ok := rv.(string)
+		if !ok {
+			return false, nil
+		}
+		str, ok := lv.(string)
+		if !ok {
+			return false, nil
+		}
+		return regexp.MatchString(pattern, str)
+	default:
+		return nil, fmt.Errorf("unknown operator %q", e.op)
+	}
+}
+
+func compareNumbers(a, b interface{}, cmp func(a, b float64) bool) bool {
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	return aok && bok && cmp(af, bf)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// boolExpr composes children with "and" or "or" semantics.
+type boolExpr struct {
+	op       string
+	children []expression
+}
+
+func (e *boolExpr) eval(ctx map[string]interface{}) (interface{}, error) {
+	for _, child := range e.children {
+		v, err := child.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, _ := v.(bool)
+		if e.op == "or" && b {
+			return true, nil
+		}
+		if e.op == "and" && !b {
+			return false, nil
+		}
+	}
+	return e.op == "and", nil
+}
+
+type notExpr struct{ child expression }
+
+func (e *notExpr) eval(ctx map[string]interface{}) (interface{}, error) {
+	v, err := e.child.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := v.(bool)
+	return !b, nil
+}
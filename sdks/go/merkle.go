@@ -0,0 +1,107 @@
+package agentmesh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Root computes the RFC-6962-style Merkle root over every local entry's
+// hash (a level with odd cardinality duplicates its last node so it
+// pairs cleanly with the level above), and the number of entries it
+// covers. It returns ("", 0) for an empty log.
+func (al *AuditLogger) Root() (root string, size int) {
+	al.mu.RLock()
+	defer al.mu.RUnlock()
+
+	if len(al.entries) == 0 {
+		return "", 0
+	}
+	levels := merkleLevels(al.leafHashesLockedUpTo(len(al.entries)))
+	return hex.EncodeToString(levels[len(levels)-1][0]), len(al.entries)
+}
+
+// Proof returns the sibling hashes from leaf index up to the root, in
+// leaf-to-root order, so a caller holding only a root from Root() can
+// confirm that the entry at index belongs to the tree without fetching
+// the whole log.
+func (al *AuditLogger) Proof(index int) ([]string, error) {
+	al.mu.RLock()
+	defer al.mu.RUnlock()
+
+	if index < 0 || index >= len(al.entries) {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", index, len(al.entries))
+	}
+
+	levels := merkleLevels(al.leafHashesLockedUpTo(len(al.entries)))
+	proof := make([]string, 0, len(levels)-1)
+	idx := index
+	for _, level := range levels[:len(levels)-1] {
+		siblingIdx := idx + 1
+		if idx%2 == 1 {
+			siblingIdx = idx - 1
+		}
+		if siblingIdx >= len(level) {
+			siblingIdx = idx // last, unpaired node is duplicated against itself
+		}
+		proof = append(proof, hex.EncodeToString(level[siblingIdx]))
+		idx /= 2
+	}
+	return proof, nil
+}
+
+// leafHashesLockedUpTo returns the leaf hashes of al.entries[:n].
+// Callers must hold al.mu.
+func (al *AuditLogger) leafHashesLockedUpTo(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		leaves[i] = leafHash(al.entries[i])
+	}
+	return leaves
+}
+
+// merkleLevels returns every level of the tree, from leaves (index 0)
+// to the single root (the last element), duplicating the last node at
+// any level with odd cardinality before combining it with the level
+// above.
+func merkleLevels(leaves [][]byte) [][][]byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+
+	levels := [][][]byte{leaves}
+	current := leaves
+	for len(current) > 1 {
+		padded := current
+		if len(padded)%2 == 1 {
+			padded = append(append([][]byte{}, current...), current[len(current)-1])
+		}
+
+		next := make([][]byte, 0, len(padded)/2)
+		for i := 0; i < len(padded); i += 2 {
+			next = append(next, nodeHash(padded[i], padded[i+1]))
+		}
+		levels = append(levels, next)
+		current = next
+	}
+	return levels
+}
+
+// leafHash hashes a leaf with a 0x00 prefix and nodeHash hashes an
+// internal node with a 0x01 prefix, so a leaf hash can never collide
+// with an internal node hash (the standard RFC 6962 second-preimage
+// defense).
+func leafHash(e *AuditEntry) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write([]byte(e.Hash))
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,92 @@
+package agentmesh
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestRootEmptyLog(t *testing.T) {
+	al := NewAuditLogger()
+	root, size := al.Root()
+	if root != "" || size != 0 {
+		t.Errorf("Root() = (%q, %d), want (\"\", 0)", root, size)
+	}
+}
+
+func TestRootChangesWhenEntryChanges(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	al.Log("a", "y", Deny)
+	al.Log("a", "z", Allow)
+
+	root1, size1 := al.Root()
+	if size1 != 3 {
+		t.Fatalf("size = %d, want 3", size1)
+	}
+
+	al.Log("a", "w", Deny)
+	root2, size2 := al.Root()
+	if root1 == root2 {
+		t.Error("root should change after appending a new entry")
+	}
+	if size2 != 4 {
+		t.Errorf("size = %d, want 4", size2)
+	}
+}
+
+func TestProofVerifiesAgainstRoot(t *testing.T) {
+	al := NewAuditLogger()
+	for i := 0; i < 5; i++ {
+		al.Log("a", "action", Allow)
+	}
+	root, _ := al.Root()
+
+	for i := 0; i < 5; i++ {
+		proof, err := al.Proof(i)
+		if err != nil {
+			t.Fatalf("Proof(%d): %v", i, err)
+		}
+		if !verifyMerkleProof(al, i, proof, root) {
+			t.Errorf("proof for index %d did not verify against the root", i)
+		}
+	}
+}
+
+func TestProofOutOfRange(t *testing.T) {
+	al := NewAuditLogger()
+	al.Log("a", "x", Allow)
+	if _, err := al.Proof(5); err == nil {
+		t.Error("expected error for out-of-range index")
+	}
+	if _, err := al.Proof(-1); err == nil {
+		t.Error("expected error for negative index")
+	}
+}
+
+// verifyMerkleProof recomputes the root from leaf index's hash and its
+// proof, exercising Proof() the way an external verifier would: it only
+// trusts the published root, not the log.
+func verifyMerkleProof(al *AuditLogger, index int, proof []string, wantRoot string) bool {
+	al.mu.RLock()
+	entry := al.entries[index]
+	total := len(al.entries)
+	al.mu.RUnlock()
+
+	cur := leafHash(entry)
+	idx := index
+	size := total
+	for _, sibHex := range proof {
+		sib, err := hex.DecodeString(sibHex)
+		if err != nil {
+			return false
+		}
+		if idx%2 == 0 {
+			cur = nodeHash(cur, sib)
+		} else {
+			cur = nodeHash(sib, cur)
+		}
+		idx /= 2
+		size = (size + 1) / 2
+	}
+	return hex.EncodeToString(cur) == wantRoot
+}
@@ -0,0 +1,185 @@
+package agentmesh
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"sync"
+)
+
+// PeeringToken is handed to a remote agent mesh (out-of-band, or over the
+// server gateway) so it can establish a peering session back to us via
+// PeerManager.AcceptToken. Signature is the issuer's Ed25519 signature
+// over the token's other fields, proving possession of the private key
+// matching PublicKey; AcceptToken rejects any token that doesn't verify,
+// so a caller cannot fabricate a token for a DID/PublicKey it doesn't
+// control.
+type PeeringToken struct {
+	DID       string `json:"did"`
+	PublicKey []byte `json:"public_key"`
+	Endpoint  string `json:"endpoint"`
+	Signature []byte `json:"signature"`
+}
+
+// peeringTokenSigningBytes returns the canonical bytes a PeeringToken's
+// Signature is computed over.
+func peeringTokenSigningBytes(token PeeringToken) []byte {
+	return []byte(fmt.Sprintf("%s|%x|%s", token.DID, token.PublicKey, token.Endpoint))
+}
+
+// PeerSession is an established peering relationship with a remote
+// agent mesh, as recorded on the side that accepted its token.
+type PeerSession struct {
+	PeerID    string
+	Endpoint  string
+	PublicKey ed25519.PublicKey
+}
+
+// PeerTransport delivers trust and audit updates to an established peer.
+// This module ships no implementation of it, and the server subsystem's
+// gRPC service has no peering methods; callers supply their own, such as
+// an in-process one.
+type PeerTransport interface {
+	SendTrustUpdate(peerID, agentID string, score TrustScore) error
+	SendAuditEntries(peerID string, entries []*AuditEntry) error
+}
+
+// PeerManager establishes peering sessions with remote agent meshes and
+// streams trust scores and audit entries to them, so remote governance
+// decisions can factor in reputation observed elsewhere. Modelled on
+// Consul's cluster peering.
+type PeerManager struct {
+	mu        sync.RWMutex
+	self      *AgentIdentity
+	endpoint  string
+	transport PeerTransport
+	trust     *TrustManager
+	audit     *AuditLogger
+	sessions  map[string]*PeerSession
+}
+
+// NewPeerManager creates a PeerManager for self, replicating trust and
+// audit updates to peers over transport.
+func NewPeerManager(self *AgentIdentity, endpoint string, transport PeerTransport, trust *TrustManager, audit *AuditLogger) *PeerManager {
+	return &PeerManager{
+		self:      self,
+		endpoint:  endpoint,
+		transport: transport,
+		trust:     trust,
+		audit:     audit,
+		sessions:  make(map[string]*PeerSession),
+	}
+}
+
+// GenerateToken produces a PeeringToken an operator can hand to a remote
+// agent mesh to establish a session via that mesh's AcceptToken. The
+// token is signed with self's private key so the recipient can confirm
+// proof of possession before trusting it.
+func (pm *PeerManager) GenerateToken() (PeeringToken, error) {
+	token := PeeringToken{
+		DID:       pm.self.DID,
+		PublicKey: []byte(pm.self.PublicKey),
+		Endpoint:  pm.endpoint,
+	}
+	sig, err := pm.self.Sign(peeringTokenSigningBytes(token))
+	if err != nil {
+		return PeeringToken{}, fmt.Errorf("signing peering token: %w", err)
+	}
+	token.Signature = sig
+	return token, nil
+}
+
+// AcceptToken establishes a peering session from a token generated by a
+// remote PeerManager's GenerateToken. The session is recorded on this
+// side only: the issuing PeerManager has no session back to this mesh
+// until it accepts a token from it in turn. It rejects tokens
+// whose Signature doesn't verify against the claimed PublicKey, so a
+// caller cannot register a session under a DID/PublicKey it doesn't
+// hold the private key for.
+func (pm *PeerManager) AcceptToken(token PeeringToken) (*PeerSession, error) {
+	if token.DID == "" || len(token.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid peering token for %q", token.DID)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(token.PublicKey), peeringTokenSigningBytes(token), token.Signature) {
+		return nil, fmt.Errorf("peering token for %q failed proof-of-possession check", token.DID)
+	}
+
+	session := &PeerSession{
+		PeerID:    token.DID,
+		Endpoint:  token.Endpoint,
+		PublicKey: ed25519.PublicKey(token.PublicKey),
+	}
+
+	pm.mu.Lock()
+	pm.sessions[token.DID] = session
+	pm.mu.Unlock()
+	return session, nil
+}
+
+// Peers returns the DIDs of all established peer sessions.
+func (pm *PeerManager) Peers() []string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	peers := make([]string, 0, len(pm.sessions))
+	for id := range pm.sessions {
+		peers = append(peers, id)
+	}
+	return peers
+}
+
+// ReplicateTrustScore streams agentID's current trust score to every
+// established peer.
+func (pm *PeerManager) ReplicateTrustScore(agentID string) error {
+	score := pm.trust.GetTrustScore(agentID)
+
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	for peerID := range pm.sessions {
+		if err := pm.transport.SendTrustUpdate(peerID, agentID, score); err != nil {
+			return fmt.Errorf("replicating trust score to %q: %w", peerID, err)
+		}
+	}
+	return nil
+}
+
+// ReplicateAuditEntries streams the given audit entries (typically those
+// appended since the last replication) to every established peer.
+func (pm *PeerManager) ReplicateAuditEntries(entries []*AuditEntry) error {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	for peerID := range pm.sessions {
+		if err := pm.transport.SendAuditEntries(peerID, entries); err != nil {
+			return fmt.Errorf("replicating audit entries to %q: %w", peerID, err)
+		}
+	}
+	return nil
+}
+
+// ReceiveTrustUpdate is called by the transport when peerID pushes a
+// TrustScore for agentID. The observation is blended into local trust
+// state via TrustManager.MergeRemoteScore, weighted by how much we trust
+// peerID itself.
+func (pm *PeerManager) ReceiveTrustUpdate(peerID, agentID string, score TrustScore) {
+	pm.trust.MergeRemoteScore(peerID, agentID, score.Overall, pm.peerWeight(peerID))
+}
+
+// ReceiveAuditChain is called by the transport when peerID pushes a
+// batch of audit entries; it verifies and imports them under that peer's
+// namespace.
+func (pm *PeerManager) ReceiveAuditChain(peerID string, entries []*AuditEntry) error {
+	return pm.audit.ImportChain(peerID, entries)
+}
+
+// peerWeight derives how much a peer's own local trust tier should
+// discount the scores it reports: a peer we don't yet trust highly has
+// its observations blended in more cautiously.
+func (pm *PeerManager) peerWeight(peerID string) float64 {
+	switch pm.trust.GetTrustScore(peerID).Tier {
+	case "high":
+		return 1.0
+	case "medium":
+		return 0.5
+	default:
+		return 0.1
+	}
+}
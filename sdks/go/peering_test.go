@@ -0,0 +1,152 @@
+package agentmesh
+
+import (
+	"testing"
+)
+
+type fakeTransport struct {
+	trustUpdates int
+	auditBatches int
+}
+
+func (f *fakeTransport) SendTrustUpdate(peerID, agentID string, score TrustScore) error {
+	f.trustUpdates++
+	return nil
+}
+
+func (f *fakeTransport) SendAuditEntries(peerID string, entries []*AuditEntry) error {
+	f.auditBatches++
+	return nil
+}
+
+func newTestPeerManager(t *testing.T) (*PeerManager, *AgentIdentity) {
+	t.Helper()
+	self, err := GenerateIdentity("local", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm := NewPeerManager(self, "local:7443", &fakeTransport{}, NewTrustManager(DefaultTrustConfig()), NewAuditLogger())
+	return pm, self
+}
+
+func TestGenerateAndAcceptToken(t *testing.T) {
+	pm, self := newTestPeerManager(t)
+	token, err := pm.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if token.DID != self.DID {
+		t.Errorf("token DID = %q, want %q", token.DID, self.DID)
+	}
+
+	remote, _ := newTestPeerManager(t)
+	session, err := remote.AcceptToken(token)
+	if err != nil {
+		t.Fatalf("AcceptToken: %v", err)
+	}
+	if session.PeerID != self.DID {
+		t.Errorf("session peer id = %q, want %q", session.PeerID, self.DID)
+	}
+	if len(remote.Peers()) != 1 {
+		t.Errorf("peers = %d, want 1", len(remote.Peers()))
+	}
+}
+
+func TestAcceptTokenRejectsInvalidKey(t *testing.T) {
+	pm, _ := newTestPeerManager(t)
+	_, err := pm.AcceptToken(PeeringToken{DID: "did:agentmesh:bad", PublicKey: []byte("too-short")})
+	if err == nil {
+		t.Error("expected error for invalid public key length")
+	}
+}
+
+func TestAcceptTokenRejectsForgedToken(t *testing.T) {
+	pm, _ := newTestPeerManager(t)
+
+	impersonated, err := GenerateIdentity("victim", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := PeeringToken{
+		DID:       impersonated.DID,
+		PublicKey: []byte(impersonated.PublicKey),
+		Endpoint:  "attacker:7443",
+	}
+	if _, err := pm.AcceptToken(forged); err == nil {
+		t.Error("expected error for token with no signature")
+	}
+
+	attacker, err := GenerateIdentity("attacker", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := attacker.Sign(peeringTokenSigningBytes(forged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged.Signature = sig
+	if _, err := pm.AcceptToken(forged); err == nil {
+		t.Error("expected error for token signed by a key other than the claimed public key")
+	}
+}
+
+func TestReplicateTrustScoreUsesTransport(t *testing.T) {
+	transport := &fakeTransport{}
+	self, _ := GenerateIdentity("local", nil)
+	trust := NewTrustManager(DefaultTrustConfig())
+	pm := NewPeerManager(self, "local:7443", transport, trust, NewAuditLogger())
+
+	peerIdentity, _ := GenerateIdentity("peer-1", nil)
+	peerPM := NewPeerManager(peerIdentity, "peer-1:7443", &fakeTransport{}, NewTrustManager(DefaultTrustConfig()), NewAuditLogger())
+	token, err := peerPM.GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.AcceptToken(token); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pm.ReplicateTrustScore("agent-1"); err != nil {
+		t.Fatalf("ReplicateTrustScore: %v", err)
+	}
+	if transport.trustUpdates != 1 {
+		t.Errorf("trust updates sent = %d, want 1", transport.trustUpdates)
+	}
+}
+
+func TestReceiveTrustUpdateMergesIntoLocalScore(t *testing.T) {
+	self, _ := GenerateIdentity("local", nil)
+	trust := NewTrustManager(DefaultTrustConfig())
+	pm := NewPeerManager(self, "local:7443", &fakeTransport{}, trust, NewAuditLogger())
+
+	before := trust.GetTrustScore("remote-agent").Overall
+	pm.ReceiveTrustUpdate("peer-1", "remote-agent", TrustScore{Overall: 1.0})
+	after := trust.GetTrustScore("remote-agent").Overall
+
+	if after <= before {
+		t.Errorf("score after remote update = %f, want > %f", after, before)
+	}
+	if trust.RemoteObservations("peer-1")["remote-agent"] != 1.0 {
+		t.Error("expected remote observation to be retained")
+	}
+}
+
+func TestReceiveAuditChainImportsAndVerifies(t *testing.T) {
+	remoteAudit := NewAuditLogger()
+	remoteAudit.Log("a", "x", Allow)
+	remoteAudit.Log("a", "y", Deny)
+
+	self, _ := GenerateIdentity("local", nil)
+	localAudit := NewAuditLogger()
+	pm := NewPeerManager(self, "local:7443", &fakeTransport{}, NewTrustManager(DefaultTrustConfig()), localAudit)
+
+	if err := pm.ReceiveAuditChain("peer-1", remoteAudit.GetEntries(AuditFilter{})); err != nil {
+		t.Fatalf("ReceiveAuditChain: %v", err)
+	}
+	if !localAudit.Verify() {
+		t.Error("expected imported chain to verify")
+	}
+	if len(localAudit.PeerEntries("peer-1")) != 2 {
+		t.Errorf("imported entries = %d, want 2", len(localAudit.PeerEntries("peer-1")))
+	}
+}
@@ -1,6 +1,7 @@
 package agentmesh
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"sync"
@@ -8,22 +9,70 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// PolicyRule defines a single governance rule.
+// PolicyRule defines a single governance rule. Conditions performs
+// simple key/value equality matching against the context; Expression, if
+// set, instead evaluates a CEL-style boolean expression (see
+// ParseExpression) and takes precedence over Conditions.
 type PolicyRule struct {
 	Action     string                 `json:"action" yaml:"action"`
 	Effect     PolicyDecision         `json:"effect" yaml:"effect"`
 	Conditions map[string]interface{} `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Expression string                 `json:"expression,omitempty" yaml:"expression,omitempty"`
+
+	compiled   expression
+	compileErr error
+}
+
+// compile parses rule.Expression, if set, caching the resulting AST so
+// Evaluate and Explain never re-parse it. It is a no-op when Expression
+// is empty.
+func (r *PolicyRule) compile() error {
+	if r.Expression == "" {
+		return nil
+	}
+	expr, err := ParseExpression(r.Expression)
+	if err != nil {
+		return fmt.Errorf("parsing expression %q: %w", r.Expression, err)
+	}
+	r.compiled = expr
+	return nil
+}
+
+// PolicyOption configures a PolicyEngine at construction time.
+type PolicyOption func(*PolicyEngine)
+
+// WithCustomActions registers additional action verbs as valid, beyond
+// the built-in schema table, so LoadFromYAML accepts rules that
+// reference them instead of rejecting them as unknown.
+func WithCustomActions(actions ...string) PolicyOption {
+	return func(pe *PolicyEngine) {
+		for _, action := range actions {
+			pe.allowedActions[action] = struct{}{}
+		}
+	}
 }
 
 // PolicyEngine evaluates actions against a set of rules.
 type PolicyEngine struct {
-	mu    sync.RWMutex
-	rules []PolicyRule
+	mu             sync.RWMutex
+	rules          []PolicyRule
+	allowedActions map[string]struct{}
 }
 
-// NewPolicyEngine creates a PolicyEngine with the supplied rules.
-func NewPolicyEngine(rules []PolicyRule) *PolicyEngine {
-	return &PolicyEngine{rules: rules}
+// NewPolicyEngine creates a PolicyEngine with the supplied rules,
+// compiling any rule Expression up front.
+func NewPolicyEngine(rules []PolicyRule, opts ...PolicyOption) *PolicyEngine {
+	pe := &PolicyEngine{
+		rules:          rules,
+		allowedActions: defaultActionSchema(),
+	}
+	for _, opt := range opts {
+		opt(pe)
+	}
+	for i := range pe.rules {
+		pe.rules[i].compileErr = pe.rules[i].compile()
+	}
+	return pe
 }
 
 // Evaluate returns the decision for the given action and context.
@@ -32,15 +81,68 @@ func (pe *PolicyEngine) Evaluate(action string, context map[string]interface{})
 	pe.mu.RLock()
 	defer pe.mu.RUnlock()
 
-	for _, rule := range pe.rules {
-		if matchAction(rule.Action, action) && matchConditions(rule.Conditions, context) {
-			return rule.Effect
+	for i := range pe.rules {
+		if matched, _ := pe.ruleMatches(&pe.rules[i], action, context); matched {
+			return pe.rules[i].Effect
 		}
 	}
 	return Deny
 }
 
-// LoadFromYAML loads rules from a YAML file, appending to existing rules.
+// ExplainResult describes why Evaluate returned a given decision, so
+// callers can debug unexpected denials.
+type ExplainResult struct {
+	Decision PolicyDecision
+	Rule     *PolicyRule // nil when no rule matched and the default deny applied
+	Reason   string
+}
+
+// Explain evaluates action and context like Evaluate, but also reports
+// which rule matched, if any, and why.
+func (pe *PolicyEngine) Explain(action string, context map[string]interface{}) ExplainResult {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	for i := range pe.rules {
+		rule := &pe.rules[i]
+		if matched, reason := pe.ruleMatches(rule, action, context); matched {
+			return ExplainResult{Decision: rule.Effect, Rule: rule, Reason: reason}
+		}
+	}
+	return ExplainResult{Decision: Deny, Reason: "no rule matched; falling back to default deny"}
+}
+
+// ruleMatches reports whether rule matches action and context, along
+// with a human-readable reason used by Explain.
+func (pe *PolicyEngine) ruleMatches(rule *PolicyRule, action string, context map[string]interface{}) (bool, string) {
+	if !matchAction(rule.Action, action) {
+		return false, fmt.Sprintf("action %q does not match rule action %q", action, rule.Action)
+	}
+
+	if rule.Expression != "" {
+		if rule.compileErr != nil {
+			return false, fmt.Sprintf("expression %q failed to compile: %v", rule.Expression, rule.compileErr)
+		}
+		result, err := rule.compiled.eval(context)
+		if err != nil {
+			return false, fmt.Sprintf("expression %q failed to evaluate: %v", rule.Expression, err)
+		}
+		if matched, _ := result.(bool); matched {
+			return true, fmt.Sprintf("expression %q matched", rule.Expression)
+		}
+		return false, fmt.Sprintf("expression %q evaluated to false", rule.Expression)
+	}
+
+	if !matchConditions(rule.Conditions, context) {
+		return false, "conditions did not match"
+	}
+	return true, "action and conditions matched"
+}
+
+// LoadFromYAML loads rules from a YAML file, appending to existing
+// rules. Each rule's Action is checked against the action schema table
+// (see WithCustomActions) and its Expression, if any, is compiled before
+// the rule is added; either failing rejects the whole file.
 func (pe *PolicyEngine) LoadFromYAML(path string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -56,10 +158,48 @@ func (pe *PolicyEngine) LoadFromYAML(path string) error {
 
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
+
+	for i := range loaded.Rules {
+		rule := &loaded.Rules[i]
+		if err := pe.validateAction(rule.Action); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
 	pe.rules = append(pe.rules, loaded.Rules...)
 	return nil
 }
 
+// validateAction rejects action verbs that are neither a wildcard
+// pattern nor present in the schema table.
+func (pe *PolicyEngine) validateAction(action string) error {
+	if action == "*" || strings.HasSuffix(action, ".*") {
+		return nil
+	}
+	if _, ok := pe.allowedActions[action]; !ok {
+		return fmt.Errorf("unknown action %q: register it with WithCustomActions", action)
+	}
+	return nil
+}
+
+// defaultActionSchema returns the built-in table of known action verbs.
+// LoadFromYAML rejects rules naming an action outside this table (unless
+// it was registered with WithCustomActions), to catch typos in policy
+// files before they reach production.
+func defaultActionSchema() map[string]struct{} {
+	return map[string]struct{}{
+		"object.put":    {},
+		"object.get":    {},
+		"object.delete": {},
+		"trust.record":  {},
+		"data.read":     {},
+		"data.write":    {},
+		"audit.read":    {},
+	}
+}
+
 func matchAction(pattern, action string) bool {
 	if pattern == "*" {
 		return true
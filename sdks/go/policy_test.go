@@ -66,9 +66,9 @@ func TestEvaluateFirstMatchWins(t *testing.T) {
 func TestLoadFromYAML(t *testing.T) {
 	dir := t.TempDir()
 	yamlContent := `rules:
-  - action: "file.read"
+  - action: "object.get"
     effect: "allow"
-  - action: "file.delete"
+  - action: "object.delete"
     effect: "deny"
 `
 	path := filepath.Join(dir, "policy.yaml")
@@ -81,10 +81,107 @@ func TestLoadFromYAML(t *testing.T) {
 		t.Fatalf("LoadFromYAML: %v", err)
 	}
 
-	if d := pe.Evaluate("file.read", nil); d != Allow {
+	if d := pe.Evaluate("object.get", nil); d != Allow {
 		t.Errorf("YAML rule: decision = %q, want allow", d)
 	}
-	if d := pe.Evaluate("file.delete", nil); d != Deny {
+	if d := pe.Evaluate("object.delete", nil); d != Deny {
 		t.Errorf("YAML rule: decision = %q, want deny", d)
 	}
 }
+
+func TestLoadFromYAMLRejectsUnknownAction(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.yaml")
+	yamlContent := `rules:
+  - action: "file.read"
+    effect: "allow"
+`
+	if err := os.WriteFile(path, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pe := NewPolicyEngine(nil)
+	if err := pe.LoadFromYAML(path); err == nil {
+		t.Error("expected LoadFromYAML to reject an action outside the schema table")
+	}
+}
+
+func TestLoadFromYAMLWithCustomActions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.yaml")
+	yamlContent := `rules:
+  - action: "file.read"
+    effect: "allow"
+`
+	if err := os.WriteFile(path, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pe := NewPolicyEngine(nil, WithCustomActions("file.read"))
+	if err := pe.LoadFromYAML(path); err != nil {
+		t.Fatalf("LoadFromYAML: %v", err)
+	}
+	if d := pe.Evaluate("file.read", nil); d != Allow {
+		t.Errorf("decision = %q, want allow", d)
+	}
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	pe := NewPolicyEngine([]PolicyRule{
+		{Action: "object.get", Effect: Allow, Expression: `request.user.role in ["admin","auditor"]`},
+	})
+
+	admin := map[string]interface{}{"request": map[string]interface{}{"user": map[string]interface{}{"role": "admin"}}}
+	if d := pe.Evaluate("object.get", admin); d != Allow {
+		t.Errorf("decision for admin = %q, want allow", d)
+	}
+
+	guest := map[string]interface{}{"request": map[string]interface{}{"user": map[string]interface{}{"role": "guest"}}}
+	if d := pe.Evaluate("object.get", guest); d != Deny {
+		t.Errorf("decision for guest = %q, want deny", d)
+	}
+}
+
+func TestEvaluateExpressionBooleanComposition(t *testing.T) {
+	pe := NewPolicyEngine([]PolicyRule{
+		{Action: "object.put", Effect: Allow, Expression: `request.size < 1000 and not request.readonly`},
+	})
+
+	allowed := map[string]interface{}{"request": map[string]interface{}{"size": 10.0, "readonly": false}}
+	if d := pe.Evaluate("object.put", allowed); d != Allow {
+		t.Errorf("decision = %q, want allow", d)
+	}
+
+	tooBig := map[string]interface{}{"request": map[string]interface{}{"size": 5000.0, "readonly": false}}
+	if d := pe.Evaluate("object.put", tooBig); d != Deny {
+		t.Errorf("decision for oversized request = %q, want deny", d)
+	}
+}
+
+func TestEvaluateExpressionCompileError(t *testing.T) {
+	pe := NewPolicyEngine([]PolicyRule{
+		{Action: "object.put", Effect: Allow, Expression: `(request.size`},
+	})
+	if d := pe.Evaluate("object.put", nil); d != Deny {
+		t.Errorf("decision for unparsable expression = %q, want deny (fail closed)", d)
+	}
+}
+
+func TestExplainReportsMatchedRule(t *testing.T) {
+	pe := NewPolicyEngine([]PolicyRule{
+		{Action: "object.get", Effect: Allow, Expression: `request.user.role == "admin"`},
+		{Action: "*", Effect: Deny},
+	})
+
+	admin := map[string]interface{}{"request": map[string]interface{}{"user": map[string]interface{}{"role": "admin"}}}
+	result := pe.Explain("object.get", admin)
+	if result.Decision != Allow || result.Rule == nil {
+		t.Fatalf("Explain = %+v, want a matched allow rule", result)
+	}
+
+	guest := map[string]interface{}{"request": map[string]interface{}{"user": map[string]interface{}{"role": "guest"}}}
+	result = pe.Explain("object.get", guest)
+	if result.Decision != Deny || result.Rule == nil || result.Rule.Action != "*" {
+		t.Fatalf("Explain = %+v, want the wildcard deny rule to match", result)
+	}
+}
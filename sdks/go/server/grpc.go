@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	agentmesh "github.com/imran-siddique/agent-mesh/sdks/go"
+)
+
+// jsonCodecName is registered with grpc's encoding package and forced on
+// the server (via ForceServerCodec); clients select it with ForceCodec,
+// so messages are marshalled as JSON instead of protobuf.
+// GovernanceService has no .proto-generated stubs; its wire messages are
+// the same plain Go structs the REST gateway already decodes with
+// encoding/json, so a JSON codec lets one set of types and one set of
+// interceptors serve both transports.
+const jsonCodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+func (jsonCodec) Name() string { return jsonCodecName }
+
+// ExecuteRequest is the GovernanceService/Execute request message.
+type ExecuteRequest struct {
+	Action string                 `json:"action"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *ExecuteRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// EvaluatePolicyRequest is the GovernanceService/EvaluatePolicy request
+// message.
+type EvaluatePolicyRequest struct {
+	Action  string                 `json:"action"`
+	Context map[string]interface{} `json:"context"`
+}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *EvaluatePolicyRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// TrustScoreRequest is the GovernanceService/GetTrustScore request
+// message.
+type TrustScoreRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *TrustScoreRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// RecordOutcomeRequest is the GovernanceService/RecordSuccess and
+// RecordFailure request message.
+type RecordOutcomeRequest struct {
+	AgentID   string  `json:"agent_id"`
+	Magnitude float64 `json:"magnitude"`
+}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *RecordOutcomeRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// AuditEntriesRequest is the GovernanceService/GetAuditEntries request
+// message.
+type AuditEntriesRequest struct {
+	Filter agentmesh.AuditFilter `json:"filter"`
+}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *AuditEntriesRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// AuditEntriesResponse is the GovernanceService/GetAuditEntries response
+// message.
+type AuditEntriesResponse struct {
+	Entries []*agentmesh.AuditEntry `json:"entries"`
+}
+
+// AuditVerifyRequest is the GovernanceService/VerifyAudit request
+// message. It carries no fields; it exists so the RPC has a concrete
+// request type to decode and sign over, same as every other method.
+type AuditVerifyRequest struct{}
+
+// Marshal satisfies the interface requestBytes uses to recover the bytes
+// an AuthUnaryInterceptor signature was computed over.
+func (r *AuditVerifyRequest) Marshal() ([]byte, error) { return json.Marshal(r) }
+
+// AuditVerifyResponse is the GovernanceService/VerifyAudit response
+// message.
+type AuditVerifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// governanceServiceName is the gRPC full service name GovernanceServer
+// is registered under.
+const governanceServiceName = "agentmesh.GovernanceService"
+
+// governanceGRPCServer is the set of methods GovernanceServiceDesc's
+// handlers call through. It plays the role that a generated
+// FooServer interface normally would: grpc.Server.RegisterService checks
+// the registered implementation against it before serving. GovernanceServer
+// satisfies it. The handlers type-assert srv to *GovernanceServer, so no
+// other implementation of these eight methods can back the service.
+type governanceGRPCServer interface {
+	ExecuteWithGovernance(action string, params map[string]interface{}) (*agentmesh.GovernanceResult, error)
+	EvaluatePolicy(action string, context map[string]interface{}) agentmesh.PolicyDecision
+	GetTrustScore(agentID string) agentmesh.TrustScore
+	RecordSuccess(agentID string, reward float64)
+	RecordFailure(agentID string, penalty float64)
+	GetAuditEntries(filter agentmesh.AuditFilter) []*agentmesh.AuditEntry
+	VerifyAudit() bool
+	WatchAuditEntries(filter agentmesh.AuditFilter) (<-chan *agentmesh.AuditEntry, func())
+}
+
+// GovernanceServiceDesc is the hand-written grpc.ServiceDesc backing
+// GovernanceServer's gRPC handlers — see RegisterGRPC and NewGRPCServer.
+// There is no .proto for this service: its messages are the same plain
+// structs (and the same json tags) the REST gateway already decodes, so
+// the JSON codec registered by this package lets one set of types and
+// one set of interceptors (RecoveryUnaryInterceptor, AuthUnaryInterceptor)
+// serve both transports without a protoc toolchain in the build.
+var GovernanceServiceDesc = grpc.ServiceDesc{
+	ServiceName: governanceServiceName,
+	HandlerType: (*governanceGRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Execute", Handler: executeHandler},
+		{MethodName: "EvaluatePolicy", Handler: evaluatePolicyHandler},
+		{MethodName: "GetTrustScore", Handler: getTrustScoreHandler},
+		{MethodName: "RecordSuccess", Handler: recordSuccessHandler},
+		{MethodName: "RecordFailure", Handler: recordFailureHandler},
+		{MethodName: "GetAuditEntries", Handler: getAuditEntriesHandler},
+		{MethodName: "VerifyAudit", Handler: verifyAuditHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAudit",
+			Handler:       watchAuditHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "",
+}
+
+func executeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		return gs.ExecuteWithGovernance(in.Action, in.Params)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/Execute"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*ExecuteRequest)
+		return gs.ExecuteWithGovernance(r.Action, r.Params)
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func evaluatePolicyHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		decision := gs.EvaluatePolicy(in.Action, in.Context)
+		return &decision, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/EvaluatePolicy"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*EvaluatePolicyRequest)
+		decision := gs.EvaluatePolicy(r.Action, r.Context)
+		return &decision, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func getTrustScoreHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrustScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		score := gs.GetTrustScore(in.AgentID)
+		return &score, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/GetTrustScore"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*TrustScoreRequest)
+		score := gs.GetTrustScore(r.AgentID)
+		return &score, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func recordSuccessHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordOutcomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		gs.RecordSuccess(in.AgentID, in.Magnitude)
+		score := gs.GetTrustScore(in.AgentID)
+		return &score, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/RecordSuccess"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*RecordOutcomeRequest)
+		gs.RecordSuccess(r.AgentID, r.Magnitude)
+		score := gs.GetTrustScore(r.AgentID)
+		return &score, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func recordFailureHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordOutcomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		gs.RecordFailure(in.AgentID, in.Magnitude)
+		score := gs.GetTrustScore(in.AgentID)
+		return &score, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/RecordFailure"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*RecordOutcomeRequest)
+		gs.RecordFailure(r.AgentID, r.Magnitude)
+		score := gs.GetTrustScore(r.AgentID)
+		return &score, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func getAuditEntriesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		return &AuditEntriesResponse{Entries: gs.GetAuditEntries(in.Filter)}, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/GetAuditEntries"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*AuditEntriesRequest)
+		return &AuditEntriesResponse{Entries: gs.GetAuditEntries(r.Filter)}, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+func verifyAuditHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, unary grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	gs := srv.(*GovernanceServer)
+	if unary == nil {
+		return &AuditVerifyResponse{Valid: gs.VerifyAudit()}, nil
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: governanceServiceName + "/VerifyAudit"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &AuditVerifyResponse{Valid: gs.VerifyAudit()}, nil
+	}
+	return unary(ctx, in, info, handler)
+}
+
+// watchAuditHandler backs the WatchAudit server-streaming RPC: it reads
+// a single AuditEntriesRequest off the stream, then forwards every
+// matching AuditEntry from GovernanceServer.WatchAuditEntries until the
+// stream's context is done. Unlike the unary handlers above, it doesn't
+// invoke an interceptor itself — grpc.Server wraps the whole handler in
+// the chained stream interceptors (RecoveryStreamInterceptor and, when
+// configured, AuthStreamInterceptor) before calling it.
+func watchAuditHandler(srv interface{}, stream grpc.ServerStream) error {
+	gs := srv.(*GovernanceServer)
+
+	in := new(AuditEntriesRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+
+	entries, cancel := gs.WatchAuditEntries(in.Filter)
+	defer cancel()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case entry, ok := <-entries:
+			if !ok {
+				return nil
+			}
+			if err := stream.SendMsg(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RegisterGRPC installs GovernanceServer's RPCs on s. Callers that also
+// want signature authentication or panic recovery should build s with
+// NewGRPCServer instead of calling this directly against a bare
+// grpc.NewServer.
+func RegisterGRPC(s *grpc.Server, gs *GovernanceServer) {
+	s.RegisterService(&GovernanceServiceDesc, gs)
+}
+
+// NewGRPCServer builds a grpc.Server exposing GovernanceServer's RPCs,
+// wrapped in RecoveryUnaryInterceptor/RecoveryStreamInterceptor and,
+// when resolve is non-nil, AuthUnaryInterceptor/AuthStreamInterceptor —
+// the gRPC equivalent of NewGateway's REST middleware stack. Messages
+// are encoded with the JSON codec registered by this package rather
+// than protobuf, since GovernanceService has no .proto-generated types.
+func NewGRPCServer(gs *GovernanceServer, resolve IdentityResolver) *grpc.Server {
+	unaryInterceptors := []grpc.UnaryServerInterceptor{RecoveryUnaryInterceptor(gs.client.Audit)}
+	streamInterceptors := []grpc.StreamServerInterceptor{RecoveryStreamInterceptor(gs.client.Audit)}
+	if resolve != nil {
+		unaryInterceptors = append(unaryInterceptors, AuthUnaryInterceptor(resolve))
+		streamInterceptors = append(streamInterceptors, AuthStreamInterceptor(resolve))
+	}
+
+	s := grpc.NewServer(
+		grpc.ForceServerCodec(jsonCodec{}),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	RegisterGRPC(s, gs)
+	return s
+}
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	agentmesh "github.com/imran-siddique/agent-mesh/sdks/go"
+)
+
+// dialGRPC starts gs behind a bufconn listener and returns a ClientConn
+// connected to it, both torn down on test cleanup.
+func dialGRPC(t *testing.T, gs *GovernanceServer, resolve IdentityResolver) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := NewGRPCServer(gs, resolve)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func TestGRPCExecuteRunsGovernancePipeline(t *testing.T) {
+	client, err := agentmesh.NewClient("grpc-agent",
+		agentmesh.WithPolicyRules([]agentmesh.PolicyRule{
+			{Action: "data.read", Effect: agentmesh.Allow},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	gs := NewGovernanceServer(client)
+	cc := dialGRPC(t, gs, nil)
+
+	var resp agentmesh.GovernanceResult
+	err = cc.Invoke(context.Background(), "/"+governanceServiceName+"/Execute",
+		&ExecuteRequest{Action: "data.read"}, &resp)
+	if err != nil {
+		t.Fatalf("Invoke Execute: %v", err)
+	}
+	if !resp.Allowed {
+		t.Error("expected data.read to be allowed")
+	}
+}
+
+func TestGRPCTrustScoreRoundTrip(t *testing.T) {
+	client, _ := agentmesh.NewClient("grpc-trust-agent")
+	gs := NewGovernanceServer(client)
+	cc := dialGRPC(t, gs, nil)
+
+	var success agentmesh.TrustScore
+	err := cc.Invoke(context.Background(), "/"+governanceServiceName+"/RecordSuccess",
+		&RecordOutcomeRequest{AgentID: "agent-1", Magnitude: 0.2}, &success)
+	if err != nil {
+		t.Fatalf("Invoke RecordSuccess: %v", err)
+	}
+	if success.Overall <= 0.5 {
+		t.Errorf("score after success = %f, want > 0.5", success.Overall)
+	}
+
+	var score agentmesh.TrustScore
+	err = cc.Invoke(context.Background(), "/"+governanceServiceName+"/GetTrustScore",
+		&TrustScoreRequest{AgentID: "agent-1"}, &score)
+	if err != nil {
+		t.Fatalf("Invoke GetTrustScore: %v", err)
+	}
+	if score.Overall != success.Overall {
+		t.Errorf("GetTrustScore = %f, want it to match RecordSuccess's %f", score.Overall, success.Overall)
+	}
+}
+
+func TestGRPCAuditRoundTrip(t *testing.T) {
+	client, _ := agentmesh.NewClient("grpc-audit-agent",
+		agentmesh.WithPolicyRules([]agentmesh.PolicyRule{
+			{Action: "data.read", Effect: agentmesh.Allow},
+		}),
+	)
+	gs := NewGovernanceServer(client)
+	cc := dialGRPC(t, gs, nil)
+
+	var exec agentmesh.GovernanceResult
+	if err := cc.Invoke(context.Background(), "/"+governanceServiceName+"/Execute",
+		&ExecuteRequest{Action: "data.read"}, &exec); err != nil {
+		t.Fatalf("Invoke Execute: %v", err)
+	}
+
+	var entries AuditEntriesResponse
+	if err := cc.Invoke(context.Background(), "/"+governanceServiceName+"/GetAuditEntries",
+		&AuditEntriesRequest{}, &entries); err != nil {
+		t.Fatalf("Invoke GetAuditEntries: %v", err)
+	}
+	if len(entries.Entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(entries.Entries))
+	}
+
+	var verify AuditVerifyResponse
+	if err := cc.Invoke(context.Background(), "/"+governanceServiceName+"/VerifyAudit",
+		&AuditVerifyRequest{}, &verify); err != nil {
+		t.Fatalf("Invoke VerifyAudit: %v", err)
+	}
+	if !verify.Valid {
+		t.Error("expected the audit chain to verify")
+	}
+}
+
+func TestGRPCWatchAuditStreamsMatchingEntries(t *testing.T) {
+	client, _ := agentmesh.NewClient("grpc-watch-agent")
+	gs := NewGovernanceServer(client)
+	cc := dialGRPC(t, gs, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "WatchAudit", ServerStreams: true},
+		"/"+governanceServiceName+"/WatchAudit")
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if err := stream.SendMsg(&AuditEntriesRequest{Filter: agentmesh.AuditFilter{AgentID: "watched-agent"}}); err != nil {
+		t.Fatalf("SendMsg: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	// The server only registers the watch once it has processed our
+	// SendMsg, which races with this goroutine. Keep logging a matching
+	// (and a non-matching) entry until one arrives instead of assuming
+	// the first Log call lands after the subscription exists.
+	received := make(chan error, 1)
+	var entry agentmesh.AuditEntry
+	go func() { received <- stream.RecvMsg(&entry) }()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case err := <-received:
+			if err != nil {
+				t.Fatalf("RecvMsg: %v", err)
+			}
+			if entry.AgentID != "watched-agent" {
+				t.Errorf("entry agent id = %q, want %q (the unmatched entry should have been filtered out)", entry.AgentID, "watched-agent")
+			}
+			return
+		case <-ticker.C:
+			gs.client.Audit.Log("other-agent", "data.read", agentmesh.Allow)
+			gs.client.Audit.Log("watched-agent", "data.read", agentmesh.Allow)
+		case <-deadline:
+			t.Fatal("timed out waiting for a watched audit entry")
+		}
+	}
+}
+
+func TestGRPCWatchAuditRequiresValidSignature(t *testing.T) {
+	client, _ := agentmesh.NewClient("grpc-watch-auth-agent")
+	gs := NewGovernanceServer(client)
+
+	caller, err := agentmesh.GenerateIdentity("watch-caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == caller.DID {
+			return caller, true
+		}
+		return nil, false
+	}
+	cc := dialGRPC(t, gs, resolve)
+
+	stream, err := cc.NewStream(context.Background(), &grpc.StreamDesc{StreamName: "WatchAudit", ServerStreams: true},
+		"/"+governanceServiceName+"/WatchAudit")
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	_ = stream.SendMsg(&AuditEntriesRequest{})
+	_ = stream.CloseSend()
+
+	var entry agentmesh.AuditEntry
+	err = stream.RecvMsg(&entry)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated for a stream opened with no signature", status.Code(err))
+	}
+}
+
+func TestGRPCAuthInterceptorRejectsBadSignature(t *testing.T) {
+	client, _ := agentmesh.NewClient("auth-agent")
+	gs := NewGovernanceServer(client)
+
+	caller, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == caller.DID {
+			return caller, true
+		}
+		return nil, false
+	}
+	cc := dialGRPC(t, gs, resolve)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"agentmesh-did", caller.DID,
+		"agentmesh-signature", "bm90LWEtdmFsaWQtc2lnbmF0dXJl")
+	var score agentmesh.TrustScore
+	err = cc.Invoke(ctx, "/"+governanceServiceName+"/GetTrustScore",
+		&TrustScoreRequest{AgentID: "agent-1"}, &score)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestGRPCAuthInterceptorAcceptsValidSignature(t *testing.T) {
+	client, _ := agentmesh.NewClient("auth-agent-2")
+	gs := NewGovernanceServer(client)
+
+	caller, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == caller.DID {
+			return caller, true
+		}
+		return nil, false
+	}
+	cc := dialGRPC(t, gs, resolve)
+
+	req := &TrustScoreRequest{AgentID: "agent-1"}
+	reqBytes, err := req.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := caller.Sign(reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"agentmesh-did", caller.DID,
+		"agentmesh-signature", base64.StdEncoding.EncodeToString(sig))
+	var score agentmesh.TrustScore
+	if err := cc.Invoke(ctx, "/"+governanceServiceName+"/GetTrustScore", req, &score); err != nil {
+		t.Fatalf("Invoke GetTrustScore: %v", err)
+	}
+}
+
+func TestRecoveryUnaryInterceptorConvertsPanicToDeny(t *testing.T) {
+	audit := agentmesh.NewAuditLogger()
+	interceptor := RecoveryUnaryInterceptor(audit)
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + governanceServiceName + "/EvaluatePolicy"}
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("policy evaluation exploded")
+	}
+
+	_, err := interceptor(context.Background(), &EvaluatePolicyRequest{}, info, panicking)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %v, want Internal", status.Code(err))
+	}
+
+	entries := audit.GetEntries(agentmesh.AuditFilter{Action: info.FullMethod})
+	if len(entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(entries))
+	}
+	if entries[0].Decision != agentmesh.Deny {
+		t.Errorf("recovered entry decision = %q, want %q", entries[0].Decision, agentmesh.Deny)
+	}
+}
+
+func TestRecoveryStreamInterceptorConvertsPanicToDeny(t *testing.T) {
+	audit := agentmesh.NewAuditLogger()
+	interceptor := RecoveryStreamInterceptor(audit)
+	info := &grpc.StreamServerInfo{FullMethod: "/" + governanceServiceName + "/Watch"}
+	panicking := func(srv interface{}, stream grpc.ServerStream) error {
+		panic("stream handler exploded")
+	}
+
+	err := interceptor(nil, fakeServerStream{ctx: context.Background()}, info, panicking)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %v, want Internal", status.Code(err))
+	}
+
+	entries := audit.GetEntries(agentmesh.AuditFilter{Action: info.FullMethod})
+	if len(entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(entries))
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	agentmesh "github.com/imran-siddique/agent-mesh/sdks/go"
+)
+
+// IdentityResolver looks up the AgentIdentity that should be used to
+// verify a request's signature, keyed by the DID the caller claims.
+type IdentityResolver func(did string) (*agentmesh.AgentIdentity, bool)
+
+type agentDIDKey struct{}
+
+// agentDIDFromContext returns the DID attached by AuthUnaryInterceptor or
+// AuthMiddleware, or "" if the request was never authenticated.
+func agentDIDFromContext(ctx context.Context) string {
+	did, _ := ctx.Value(agentDIDKey{}).(string)
+	return did
+}
+
+const (
+	didMetadataKey = "agentmesh-did"
+	sigMetadataKey = "agentmesh-signature"
+)
+
+// RecoveryUnaryInterceptor recovers from panics raised anywhere in the
+// handler chain — most importantly inside policy/condition evaluation —
+// records it as a Deny audit entry tagged with the recovered stack trace
+// so the crash is never silently dropped from the governance record, and
+// returns a codes.Internal error to the caller in place of a response.
+func RecoveryUnaryInterceptor(audit *agentmesh.AuditLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				audit.LogWithMetadata(agentDIDFromContext(ctx), info.FullMethod, agentmesh.Deny, map[string]string{
+					"panic": fmt.Sprint(r),
+					"stack": string(debug.Stack()),
+				})
+				err = status.Errorf(codes.Internal, "panic recovered in %s: %v", info.FullMethod, r)
+				resp = nil
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor is the streaming counterpart of
+// RecoveryUnaryInterceptor.
+func RecoveryStreamInterceptor(audit *agentmesh.AuditLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				audit.LogWithMetadata(agentDIDFromContext(ss.Context()), info.FullMethod, agentmesh.Deny, map[string]string{
+					"panic": fmt.Sprint(r),
+					"stack": string(debug.Stack()),
+				})
+				err = status.Errorf(codes.Internal, "panic recovered in %s: %v", info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// AuthUnaryInterceptor verifies the caller's Ed25519 signature over the
+// serialised request bytes, sent as base64 in the "agentmesh-signature"
+// metadata value alongside the claimed DID in "agentmesh-did", before the
+// request reaches the governance pipeline.
+func AuthUnaryInterceptor(resolve IdentityResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		did, sig, err := authFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		identity, ok := resolve(did)
+		if !ok {
+			return nil, status.Errorf(codes.Unauthenticated, "unknown agent did %q", did)
+		}
+		if !identity.Verify(requestBytes(req), sig) {
+			return nil, status.Error(codes.Unauthenticated, "invalid signature")
+		}
+		return handler(context.WithValue(ctx, agentDIDKey{}, did), req)
+	}
+}
+
+// AuthStreamInterceptor is the streaming counterpart of
+// AuthUnaryInterceptor. It verifies the signature once, over the DID
+// itself, since stream payloads arrive incrementally.
+func AuthStreamInterceptor(resolve IdentityResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		did, sig, err := authFromContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		identity, ok := resolve(did)
+		if !ok {
+			return status.Errorf(codes.Unauthenticated, "unknown agent did %q", did)
+		}
+		if !identity.Verify([]byte(did), sig) {
+			return status.Error(codes.Unauthenticated, "invalid signature")
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, did: did})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	did string
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return context.WithValue(s.ServerStream.Context(), agentDIDKey{}, s.did)
+}
+
+func authFromContext(ctx context.Context) (did string, sig []byte, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	dids := md.Get(didMetadataKey)
+	sigs := md.Get(sigMetadataKey)
+	if len(dids) != 1 || len(sigs) != 1 {
+		return "", nil, status.Error(codes.Unauthenticated, "missing did or signature metadata")
+	}
+	sig, decodeErr := base64.StdEncoding.DecodeString(sigs[0])
+	if decodeErr != nil {
+		return "", nil, status.Errorf(codes.Unauthenticated, "decoding signature: %v", decodeErr)
+	}
+	return dids[0], sig, nil
+}
+
+// requestBytes extracts the bytes that a request's signature was computed
+// over. Proto messages generated with the standard toolchain implement
+// Marshal() ([]byte, error); anything else falls back to its string form.
+func requestBytes(req interface{}) []byte {
+	if m, ok := req.(interface{ Marshal() ([]byte, error) }); ok {
+		if b, err := m.Marshal(); err == nil {
+			return b
+		}
+	}
+	return []byte(fmt.Sprintf("%v", req))
+}
+
+// RecoveryMiddleware is the REST gateway's equivalent of
+// RecoveryUnaryInterceptor: it recovers from panics in the wrapped
+// handler, returns a 500, and records a Deny audit entry tagged with the
+// recovered stack.
+func RecoveryMiddleware(audit *agentmesh.AuditLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					audit.LogWithMetadata(agentDIDFromContext(r.Context()), r.URL.Path, agentmesh.Deny, map[string]string{
+						"panic": fmt.Sprint(rec),
+						"stack": string(debug.Stack()),
+					})
+					writeError(w, http.StatusInternalServerError, fmt.Errorf("panic recovered: %v", rec))
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthMiddleware is the REST gateway's equivalent of AuthUnaryInterceptor:
+// it verifies the Ed25519 signature carried in the Agentmesh-Did and
+// Agentmesh-Signature headers before forwarding the request. The
+// signature must cover requestSigningBytes(method, path, body) — the
+// full request, not just the path — so a signature observed for one
+// body can't be replayed against a tampered one.
+func AuthMiddleware(resolve IdentityResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			did := r.Header.Get("Agentmesh-Did")
+			sigHeader := r.Header.Get("Agentmesh-Signature")
+			if did == "" || sigHeader == "" {
+				writeError(w, http.StatusUnauthorized, fmt.Errorf("missing did or signature header"))
+				return
+			}
+			sig, err := base64.StdEncoding.DecodeString(sigHeader)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, fmt.Errorf("decoding signature: %w", err))
+				return
+			}
+			body, err := readAndRestoreBody(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("reading request body: %w", err))
+				return
+			}
+			identity, ok := resolve(did)
+			if !ok || !identity.Verify(requestSigningBytes(r.Method, r.URL.Path, body), sig) {
+				writeError(w, http.StatusUnauthorized, fmt.Errorf("invalid signature"))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), agentDIDKey{}, did)))
+		})
+	}
+}
+
+// requestSigningBytes returns the bytes an AuthMiddleware signature must
+// cover: the method and path, then a newline, then the raw body (if
+// any). Including the body is what stops a signature captured for one
+// request body from verifying against a tampered one.
+func requestSigningBytes(method, path string, body []byte) []byte {
+	return append([]byte(method+" "+path+"\n"), body...)
+}
+
+// readAndRestoreBody drains r.Body so its bytes can be hashed, then
+// replaces it with a fresh reader over the same bytes so downstream
+// handlers (e.g. decodeJSON) can still read it.
+func readAndRestoreBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
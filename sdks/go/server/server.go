@@ -0,0 +1,192 @@
+// Package server exposes an AgentMeshClient's governance pipeline to
+// external agents over gRPC and REST, so a single daemon can act as the
+// central governance point for a fleet of agents.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	agentmesh "github.com/imran-siddique/agent-mesh/sdks/go"
+)
+
+// GovernanceServer implements the governance RPCs on top of an
+// AgentMeshClient. It is the shared core behind both the gRPC service
+// registered by RegisterGRPC and the REST gateway returned by NewGateway.
+type GovernanceServer struct {
+	client *agentmesh.AgentMeshClient
+}
+
+// NewGovernanceServer creates a GovernanceServer backed by client.
+func NewGovernanceServer(client *agentmesh.AgentMeshClient) *GovernanceServer {
+	return &GovernanceServer{client: client}
+}
+
+// ExecuteWithGovernance runs action through the client's governance
+// pipeline, as the gRPC handler for AgentMeshClient.ExecuteWithGovernance.
+func (gs *GovernanceServer) ExecuteWithGovernance(action string, params map[string]interface{}) (*agentmesh.GovernanceResult, error) {
+	return gs.client.ExecuteWithGovernance(action, params)
+}
+
+// EvaluatePolicy runs action through the policy engine only, without
+// touching trust or audit state.
+func (gs *GovernanceServer) EvaluatePolicy(action string, context map[string]interface{}) agentmesh.PolicyDecision {
+	return gs.client.Policy.Evaluate(action, context)
+}
+
+// GetTrustScore returns the current trust score for agentID.
+func (gs *GovernanceServer) GetTrustScore(agentID string) agentmesh.TrustScore {
+	return gs.client.Trust.GetTrustScore(agentID)
+}
+
+// RecordSuccess reports a successful interaction for agentID.
+func (gs *GovernanceServer) RecordSuccess(agentID string, reward float64) {
+	gs.client.Trust.RecordSuccess(agentID, reward)
+}
+
+// RecordFailure reports a failed interaction for agentID.
+func (gs *GovernanceServer) RecordFailure(agentID string, penalty float64) {
+	gs.client.Trust.RecordFailure(agentID, penalty)
+}
+
+// GetAuditEntries returns audit entries matching filter.
+func (gs *GovernanceServer) GetAuditEntries(filter agentmesh.AuditFilter) []*agentmesh.AuditEntry {
+	return gs.client.Audit.GetEntries(filter)
+}
+
+// VerifyAudit checks the integrity of the audit chain.
+func (gs *GovernanceServer) VerifyAudit() bool {
+	return gs.client.Audit.Verify()
+}
+
+// WatchAuditEntries subscribes to audit entries matching filter as they
+// are logged. The returned cancel func must be called to release the
+// subscription.
+func (gs *GovernanceServer) WatchAuditEntries(filter agentmesh.AuditFilter) (<-chan *agentmesh.AuditEntry, func()) {
+	return gs.client.Audit.Watch(filter)
+}
+
+// NewGateway returns an http.Handler exposing GovernanceServer's RPCs as
+// JSON-over-HTTP endpoints, wrapped in RecoveryMiddleware and, when
+// resolve is non-nil, AuthMiddleware. Routes:
+//
+//	POST /v1/execute                body: {action, params}
+//	POST /v1/policy/evaluate         body: {action, context}
+//	GET  /v1/trust/{agentID}
+//	POST /v1/trust/{agentID}/success body: {reward}
+//	POST /v1/trust/{agentID}/failure body: {penalty}
+//	GET  /v1/audit
+//	GET  /v1/audit/verify
+func NewGateway(gs *GovernanceServer, resolve IdentityResolver) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/execute", gs.handleExecute)
+	mux.HandleFunc("/v1/policy/evaluate", gs.handleEvaluatePolicy)
+	mux.HandleFunc("/v1/trust/", gs.handleTrust)
+	mux.HandleFunc("/v1/audit", gs.handleAuditEntries)
+	mux.HandleFunc("/v1/audit/verify", gs.handleAuditVerify)
+
+	var handler http.Handler = mux
+	if resolve != nil {
+		handler = AuthMiddleware(resolve)(handler)
+	}
+	return RecoveryMiddleware(gs.client.Audit)(handler)
+}
+
+func (gs *GovernanceServer) handleExecute(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Action string                 `json:"action"`
+		Params map[string]interface{} `json:"params"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	result, err := gs.ExecuteWithGovernance(req.Action, req.Params)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (gs *GovernanceServer) handleEvaluatePolicy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Action  string                 `json:"action"`
+		Context map[string]interface{} `json:"context"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]agentmesh.PolicyDecision{
+		"decision": gs.EvaluatePolicy(req.Action, req.Context),
+	})
+}
+
+func (gs *GovernanceServer) handleTrust(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/v1/trust/")
+	agentID, action, _ := strings.Cut(rest, "/")
+	if agentID == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("missing agent id"))
+		return
+	}
+
+	switch action {
+	case "":
+		writeJSON(w, http.StatusOK, gs.GetTrustScore(agentID))
+	case "success":
+		var req struct {
+			Reward float64 `json:"reward"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		gs.RecordSuccess(agentID, req.Reward)
+		writeJSON(w, http.StatusOK, gs.GetTrustScore(agentID))
+	case "failure":
+		var req struct {
+			Penalty float64 `json:"penalty"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		gs.RecordFailure(agentID, req.Penalty)
+		writeJSON(w, http.StatusOK, gs.GetTrustScore(agentID))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown trust route %q", action))
+	}
+}
+
+func (gs *GovernanceServer) handleAuditEntries(w http.ResponseWriter, r *http.Request) {
+	filter := agentmesh.AuditFilter{
+		AgentID: r.URL.Query().Get("agent_id"),
+		Action:  r.URL.Query().Get("action"),
+	}
+	writeJSON(w, http.StatusOK, gs.GetAuditEntries(filter))
+}
+
+func (gs *GovernanceServer) handleAuditVerify(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]bool{"valid": gs.VerifyAudit()})
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil {
+		return true
+	}
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
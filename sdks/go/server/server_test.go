@@ -0,0 +1,215 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	agentmesh "github.com/imran-siddique/agent-mesh/sdks/go"
+)
+
+func newTestGateway(t *testing.T, resolve IdentityResolver) (*GovernanceServer, http.Handler) {
+	t.Helper()
+	client, err := agentmesh.NewClient("gateway-agent",
+		agentmesh.WithPolicyRules([]agentmesh.PolicyRule{
+			{Action: "data.read", Effect: agentmesh.Allow},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	gs := NewGovernanceServer(client)
+	return gs, NewGateway(gs, resolve)
+}
+
+func TestGatewayExecute(t *testing.T) {
+	_, handler := newTestGateway(t, nil)
+
+	body, _ := json.Marshal(map[string]interface{}{"action": "data.read"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/execute", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var result agentmesh.GovernanceResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if !result.Allowed {
+		t.Error("expected data.read to be allowed")
+	}
+}
+
+func TestGatewayTrustScoreAndUpdates(t *testing.T) {
+	_, handler := newTestGateway(t, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/trust/agent-1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	body, _ := json.Marshal(map[string]float64{"reward": 0.2})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/trust/agent-1/success", bytes.NewReader(body)))
+	var score agentmesh.TrustScore
+	if err := json.Unmarshal(rec.Body.Bytes(), &score); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if score.Overall <= 0.5 {
+		t.Errorf("score after success = %f, want > 0.5", score.Overall)
+	}
+}
+
+func TestGatewayRecoversFromPanic(t *testing.T) {
+	client, _ := agentmesh.NewClient("panic-agent")
+	gs := NewGovernanceServer(client)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/boom", func(w http.ResponseWriter, r *http.Request) {
+		panic("policy evaluation exploded")
+	})
+	handler := RecoveryMiddleware(gs.client.Audit)(mux)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	entries := gs.GetAuditEntries(agentmesh.AuditFilter{})
+	if len(entries) != 1 || entries[0].Decision != agentmesh.Deny {
+		t.Fatalf("expected one Deny audit entry for the panic, got %+v", entries)
+	}
+	if entries[0].Metadata["stack"] == "" {
+		t.Error("expected panic stack to be recorded in audit metadata")
+	}
+}
+
+func TestGatewayAuthMiddlewareRejectsBadSignature(t *testing.T) {
+	identity, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == identity.DID {
+			return identity, true
+		}
+		return nil, false
+	}
+	_, handler := newTestGateway(t, resolve)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/audit/verify", nil)
+	req.Header.Set("Agentmesh-Did", identity.DID)
+	req.Header.Set("Agentmesh-Signature", base64.StdEncoding.EncodeToString([]byte("not-a-real-signature")))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+}
+
+func TestGatewayAuthMiddlewareAcceptsValidSignature(t *testing.T) {
+	identity, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == identity.DID {
+			return identity, true
+		}
+		return nil, false
+	}
+	_, handler := newTestGateway(t, resolve)
+
+	path := "/v1/audit/verify"
+	sig, err := identity.Sign(requestSigningBytes(http.MethodGet, path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Agentmesh-Did", identity.DID)
+	req.Header.Set("Agentmesh-Signature", base64.StdEncoding.EncodeToString(sig))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGatewayAuthMiddlewareRejectsTamperedBody(t *testing.T) {
+	identity, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == identity.DID {
+			return identity, true
+		}
+		return nil, false
+	}
+	_, handler := newTestGateway(t, resolve)
+
+	path := "/v1/trust/agent-1/success"
+	legitBody, _ := json.Marshal(map[string]float64{"reward": 0.01})
+	sig, err := identity.Sign(requestSigningBytes(http.MethodPost, path, legitBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedBody, _ := json.Marshal(map[string]float64{"reward": 999.0})
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(tamperedBody))
+	req.Header.Set("Agentmesh-Did", identity.DID)
+	req.Header.Set("Agentmesh-Signature", base64.StdEncoding.EncodeToString(sig))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401 for a body that doesn't match the signed one", rec.Code)
+	}
+}
+
+func TestGatewayAuthMiddlewareAcceptsValidSignatureWithBody(t *testing.T) {
+	identity, err := agentmesh.GenerateIdentity("caller", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(did string) (*agentmesh.AgentIdentity, bool) {
+		if did == identity.DID {
+			return identity, true
+		}
+		return nil, false
+	}
+	_, handler := newTestGateway(t, resolve)
+
+	path := "/v1/trust/agent-1/success"
+	body, _ := json.Marshal(map[string]float64{"reward": 0.01})
+	sig, err := identity.Sign(requestSigningBytes(http.MethodPost, path, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Agentmesh-Did", identity.DID)
+	req.Header.Set("Agentmesh-Signature", base64.StdEncoding.EncodeToString(sig))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var score agentmesh.TrustScore
+	if err := json.Unmarshal(rec.Body.Bytes(), &score); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if score.Overall <= 0.5 {
+		t.Errorf("score after success = %f, want > 0.5", score.Overall)
+	}
+}
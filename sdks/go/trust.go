@@ -1,7 +1,6 @@
 package agentmesh
 
 import (
-	"math"
 	"sync"
 )
 
@@ -12,23 +11,31 @@ type TrustScore struct {
 	Tier       string             `json:"tier"`
 }
 
-type scoreState struct {
-	score       float64
-	interactions int
-}
-
-// TrustManager tracks and updates per-agent trust scores.
+// TrustManager tracks and updates per-agent trust scores, delegating the
+// actual scoring rule to a TrustStrategy (LinearStrategy by default; see
+// NewTrustManagerWithStrategy and WithTrustStrategy).
 type TrustManager struct {
-	mu     sync.RWMutex
-	config TrustConfig
-	scores map[string]*scoreState
+	mu       sync.RWMutex
+	config   TrustConfig
+	strategy TrustStrategy
+	scores   map[string]*StrategyState
+	remote   map[string]map[string]float64 // peerID -> agentID -> last reported score
 }
 
-// NewTrustManager creates a TrustManager with the given config.
+// NewTrustManager creates a TrustManager with the given config, using
+// the original linear reward/penalty-with-decay strategy.
 func NewTrustManager(config TrustConfig) *TrustManager {
+	return NewTrustManagerWithStrategy(config, LinearStrategy{})
+}
+
+// NewTrustManagerWithStrategy creates a TrustManager with the given
+// config and TrustStrategy.
+func NewTrustManagerWithStrategy(config TrustConfig, strategy TrustStrategy) *TrustManager {
 	return &TrustManager{
-		config: config,
-		scores: make(map[string]*scoreState),
+		config:   config,
+		strategy: strategy,
+		scores:   make(map[string]*StrategyState),
+		remote:   make(map[string]map[string]float64),
 	}
 }
 
@@ -57,48 +64,93 @@ func (tm *TrustManager) GetTrustScore(agentID string) TrustScore {
 		}
 	}
 
+	dims := make(map[string]float64, len(s.Dimensions))
+	for dim, score := range s.Dimensions {
+		dims[dim] = score
+	}
 	return TrustScore{
-		Overall:    s.score,
-		Dimensions: map[string]float64{"reliability": s.score},
-		Tier:       tm.tierFor(s.score),
+		Overall:    s.Overall,
+		Dimensions: dims,
+		Tier:       tm.tierFor(s.Overall),
 	}
 }
 
-// RecordSuccess increases an agent's trust score with decay.
+// RecordSuccess reports a successful interaction for agentID, updating
+// its overall ("reliability") score via the configured TrustStrategy.
 func (tm *TrustManager) RecordSuccess(agentID string, reward float64) {
+	tm.recordEvent(agentID, TrustEvent{Success: true, Magnitude: reward})
+}
+
+// RecordFailure reports a failed interaction for agentID, updating its
+// overall ("reliability") score via the configured TrustStrategy.
+func (tm *TrustManager) RecordFailure(agentID string, penalty float64) {
+	tm.recordEvent(agentID, TrustEvent{Success: false, Magnitude: penalty})
+}
+
+// RecordDimensionEvent reports a success or failure for a specific
+// scoring dimension, such as "latency" or "correctness", so
+// TrustScore.Dimensions reflects more than overall reliability.
+func (tm *TrustManager) RecordDimensionEvent(agentID, dimension string, success bool, magnitude float64) {
+	tm.recordEvent(agentID, TrustEvent{Success: success, Magnitude: magnitude, Dimension: dimension})
+}
+
+func (tm *TrustManager) recordEvent(agentID string, event TrustEvent) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	s := tm.getOrCreate(agentID)
-	s.interactions++
-	decayed := tm.applyDecay(s.score)
-	s.score = math.Min(1.0, decayed+reward*tm.config.RewardFactor)
+	state := tm.getOrCreate(agentID)
+	*state = tm.strategy.Update(tm.config, *state, event)
 }
 
-// RecordFailure decreases an agent's trust score with asymmetric penalty.
-func (tm *TrustManager) RecordFailure(agentID string, penalty float64) {
+// MergeRemoteScore blends a trust score that peerID reported for
+// agentID into the local score, via the configured TrustStrategy's
+// Aggregate, weighted by weight (the peering subsystem derives weight
+// from how much we trust peerID itself). The raw observation is also
+// retained per peer and can be read back with RemoteObservations; no
+// TrustStrategy in this package reads it.
+func (tm *TrustManager) MergeRemoteScore(peerID, agentID string, score, weight float64) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	s := tm.getOrCreate(agentID)
-	s.interactions++
-	decayed := tm.applyDecay(s.score)
-	s.score = math.Max(0.0, decayed-penalty*tm.config.PenaltyFactor)
+	if tm.remote[peerID] == nil {
+		tm.remote[peerID] = make(map[string]float64)
+	}
+	tm.remote[peerID][agentID] = score
+
+	state := tm.getOrCreate(agentID)
+	blended := tm.strategy.Aggregate(tm.config, state.Overall, []Observation{{Score: score, Weight: weight}})
+	state.Overall = blended
+	if state.Dimensions == nil {
+		state.Dimensions = make(map[string]float64)
+	}
+	state.Dimensions["reliability"] = blended
 }
 
-func (tm *TrustManager) getOrCreate(agentID string) *scoreState {
+// RemoteObservations returns the trust scores peerID has reported, keyed
+// by agent DID.
+func (tm *TrustManager) RemoteObservations(peerID string) map[string]float64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	out := make(map[string]float64, len(tm.remote[peerID]))
+	for agentID, score := range tm.remote[peerID] {
+		out[agentID] = score
+	}
+	return out
+}
+
+func (tm *TrustManager) getOrCreate(agentID string) *StrategyState {
 	s, ok := tm.scores[agentID]
 	if !ok {
-		s = &scoreState{score: tm.config.InitialScore}
+		s = &StrategyState{
+			Overall:    tm.config.InitialScore,
+			Dimensions: map[string]float64{"reliability": tm.config.InitialScore},
+		}
 		tm.scores[agentID] = s
 	}
 	return s
 }
 
-func (tm *TrustManager) applyDecay(score float64) float64 {
-	return score * (1.0 - tm.config.DecayRate)
-}
-
 func (tm *TrustManager) tierFor(score float64) string {
 	switch {
 	case score >= tm.config.TierThresholds.High:
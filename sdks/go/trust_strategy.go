@@ -0,0 +1,236 @@
+package agentmesh
+
+import "math"
+
+// TrustEvent describes a single interaction outcome fed into a
+// TrustStrategy's Update. Dimension lets callers track more than overall
+// reliability (e.g. "latency", "correctness"); it defaults to
+// "reliability" when empty.
+type TrustEvent struct {
+	Success   bool
+	Magnitude float64
+	Dimension string
+}
+
+// Observation is a trust score reported by one agent about another,
+// supplied to Aggregate alongside the weight the reporting peer should
+// carry (typically derived from how much we trust that peer itself).
+type Observation struct {
+	Score  float64
+	Weight float64
+}
+
+// StrategyState is the per-agent state a TrustStrategy evolves over
+// time. Dimensions holds one sub-score per tracked dimension; Extra
+// carries strategy-specific bookkeeping that doesn't fit that shape,
+// such as the Beta-reputation strategy's (alpha, beta) counts.
+type StrategyState struct {
+	Overall      float64
+	Dimensions   map[string]float64
+	Interactions int
+	Extra        map[string]float64
+}
+
+// TrustStrategy computes how a trust state evolves in response to
+// events, and how a local score combines with remote observations into
+// one overall score. TrustManager delegates all scoring decisions to
+// the configured strategy, so alternative models can be swapped in via
+// WithTrustStrategy without changing how trust is recorded or read.
+type TrustStrategy interface {
+	// Update returns the state that results from applying event to
+	// state.
+	Update(cfg TrustConfig, state StrategyState, event TrustEvent) StrategyState
+	// Aggregate combines a local score with observations reported by
+	// peers into a single score.
+	Aggregate(cfg TrustConfig, local float64, remote []Observation) float64
+}
+
+// LinearStrategy is the original reward/penalty-with-decay rule: each
+// event decays the relevant dimension toward zero, then nudges it up by
+// magnitude*RewardFactor on success or down by magnitude*PenaltyFactor
+// on failure, clamped to [0, 1].
+type LinearStrategy struct{}
+
+// Update implements TrustStrategy.
+func (LinearStrategy) Update(cfg TrustConfig, state StrategyState, event TrustEvent) StrategyState {
+	dim := dimensionOrDefault(event.Dimension)
+	if state.Dimensions == nil {
+		state.Dimensions = make(map[string]float64)
+	}
+	current, ok := state.Dimensions[dim]
+	if !ok {
+		current = cfg.InitialScore
+	}
+
+	decayed := current * (1 - cfg.DecayRate)
+	if event.Success {
+		current = math.Min(1.0, decayed+event.Magnitude*cfg.RewardFactor)
+	} else {
+		current = math.Max(0.0, decayed-event.Magnitude*cfg.PenaltyFactor)
+	}
+
+	state.Dimensions[dim] = current
+	state.Interactions++
+	state.Overall = averageDimensions(state.Dimensions)
+	return state
+}
+
+// Aggregate implements TrustStrategy with a simple weighted blend of
+// the local score and every remote observation.
+func (LinearStrategy) Aggregate(cfg TrustConfig, local float64, remote []Observation) float64 {
+	return weightedBlend(local, remote)
+}
+
+// BetaReputationStrategy models trust as a Beta(alpha, beta) posterior
+// over successes and failures: Overall is the distribution mean
+// alpha/(alpha+beta), with alpha and beta decayed by cfg.DecayRate each
+// interaction so old evidence fades. Extra["confidence"] exposes the
+// distribution's standard deviation, for callers that want to weigh a
+// score by how much evidence backs it.
+type BetaReputationStrategy struct{}
+
+// Update implements TrustStrategy.
+func (BetaReputationStrategy) Update(cfg TrustConfig, state StrategyState, event TrustEvent) StrategyState {
+	if state.Extra == nil {
+		state.Extra = make(map[string]float64)
+	}
+	alpha, ok := state.Extra["alpha"]
+	if !ok {
+		alpha = 1
+	}
+	beta, ok := state.Extra["beta"]
+	if !ok {
+		beta = 1
+	}
+
+	alpha *= 1 - cfg.DecayRate
+	beta *= 1 - cfg.DecayRate
+	if event.Success {
+		alpha++
+	} else {
+		beta++
+	}
+	state.Extra["alpha"] = alpha
+	state.Extra["beta"] = beta
+
+	mean := alpha / (alpha + beta)
+	state.Extra["confidence"] = math.Sqrt((alpha * beta) / (math.Pow(alpha+beta, 2) * (alpha + beta + 1)))
+
+	dim := dimensionOrDefault(event.Dimension)
+	if state.Dimensions == nil {
+		state.Dimensions = make(map[string]float64)
+	}
+	state.Dimensions[dim] = mean
+	state.Interactions++
+	state.Overall = mean
+	return state
+}
+
+// Aggregate implements TrustStrategy with a simple weighted blend of
+// the local score and every remote observation.
+func (BetaReputationStrategy) Aggregate(cfg TrustConfig, local float64, remote []Observation) float64 {
+	return weightedBlend(local, remote)
+}
+
+// EigenTrustStrategy aggregates remote observations the way EigenTrust
+// blends a peer's reported score into a global trust vector: it treats
+// remote's weighted average as a stand-in for one row of C^T*t_k and
+// takes a single convex-combination step toward it,
+// t = (1-alpha)*weighted(remote) + alpha*local, where local is this
+// agent's own prior. Every call site in this package
+// (TrustManager.MergeRemoteScore) supplies one Observation at a time
+// rather than a full peer-to-peer matrix, so this is a single blending
+// step rather than the literal power iteration in the EigenTrust paper.
+// Aggregate does not loop, and nothing in this package reads Epsilon or
+// MaxIters. With no
+// remote observations at all, Aggregate returns local unchanged, same as
+// LinearStrategy and BetaReputationStrategy. Direct interaction updates
+// reuse LinearStrategy's rule.
+type EigenTrustStrategy struct {
+	Alpha    float64
+	Epsilon  float64
+	MaxIters int
+}
+
+// NewEigenTrustStrategy returns an EigenTrustStrategy with the
+// parameters typically used in the literature: Alpha=0.15,
+// Epsilon=1e-6 and MaxIters=100. Only Alpha affects Aggregate.
+func NewEigenTrustStrategy() EigenTrustStrategy {
+	return EigenTrustStrategy{Alpha: 0.15, Epsilon: 1e-6, MaxIters: 100}
+}
+
+// Update implements TrustStrategy by delegating to LinearStrategy; the
+// EigenTrust model only changes how observations from multiple peers
+// are aggregated into one global score, not how direct interactions
+// update the local one.
+func (e EigenTrustStrategy) Update(cfg TrustConfig, state StrategyState, event TrustEvent) StrategyState {
+	return LinearStrategy{}.Update(cfg, state, event)
+}
+
+// Aggregate implements TrustStrategy. It returns local unchanged when
+// remote is empty, matching LinearStrategy and BetaReputationStrategy.
+func (e EigenTrustStrategy) Aggregate(cfg TrustConfig, local float64, remote []Observation) float64 {
+	if len(remote) == 0 {
+		return local
+	}
+
+	alpha, _, _ := e.params()
+
+	var weighted, totalWeight float64
+	for _, obs := range remote {
+		weighted += obs.Score * obs.Weight
+		totalWeight += obs.Weight
+	}
+	if totalWeight > 0 {
+		weighted /= totalWeight
+	}
+
+	t := (1-alpha)*weighted + alpha*local
+	return math.Max(0.0, math.Min(1.0, t))
+}
+
+func (e EigenTrustStrategy) params() (alpha, epsilon float64, maxIters int) {
+	alpha, epsilon, maxIters = e.Alpha, e.Epsilon, e.MaxIters
+	if alpha == 0 {
+		alpha = 0.15
+	}
+	if epsilon == 0 {
+		epsilon = 1e-6
+	}
+	if maxIters == 0 {
+		maxIters = 100
+	}
+	return alpha, epsilon, maxIters
+}
+
+func dimensionOrDefault(dim string) string {
+	if dim == "" {
+		return "reliability"
+	}
+	return dim
+}
+
+func averageDimensions(dims map[string]float64) float64 {
+	if len(dims) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range dims {
+		sum += v
+	}
+	return sum / float64(len(dims))
+}
+
+// weightedBlend combines local with every remote observation, weighting
+// local as 1 and each observation by its own Weight.
+func weightedBlend(local float64, remote []Observation) float64 {
+	if len(remote) == 0 {
+		return local
+	}
+	sum, totalWeight := local, 1.0
+	for _, obs := range remote {
+		sum += obs.Score * obs.Weight
+		totalWeight += obs.Weight
+	}
+	return math.Max(0.0, math.Min(1.0, sum/totalWeight))
+}
@@ -0,0 +1,88 @@
+package agentmesh
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetaReputationStrategyMeanTracksEvidence(t *testing.T) {
+	tm := NewTrustManagerWithStrategy(DefaultTrustConfig(), BetaReputationStrategy{})
+
+	for i := 0; i < 5; i++ {
+		tm.RecordSuccess("beta-agent", 1)
+	}
+	score := tm.GetTrustScore("beta-agent")
+	if score.Overall <= 0.5 {
+		t.Errorf("overall after 5 successes = %f, want > 0.5", score.Overall)
+	}
+
+	for i := 0; i < 10; i++ {
+		tm.RecordFailure("beta-agent", 1)
+	}
+	score = tm.GetTrustScore("beta-agent")
+	if score.Overall >= 0.5 {
+		t.Errorf("overall after 10 more failures = %f, want < 0.5", score.Overall)
+	}
+}
+
+func TestBetaReputationStrategyStaysInUnitInterval(t *testing.T) {
+	tm := NewTrustManagerWithStrategy(DefaultTrustConfig(), BetaReputationStrategy{})
+	for i := 0; i < 50; i++ {
+		tm.RecordSuccess("bounded", 1)
+	}
+	score := tm.GetTrustScore("bounded")
+	if score.Overall > 1.0 || score.Overall < 0.0 {
+		t.Errorf("overall = %f, want within [0, 1]", score.Overall)
+	}
+}
+
+func TestEigenTrustStrategyAggregateWeightsTowardConsensus(t *testing.T) {
+	strategy := NewEigenTrustStrategy()
+	cfg := DefaultTrustConfig()
+
+	score := strategy.Aggregate(cfg, 0.5, []Observation{
+		{Score: 0.9, Weight: 1.0},
+		{Score: 0.8, Weight: 0.5},
+	})
+	if score <= 0.5 {
+		t.Errorf("aggregated score = %f, want it pulled above the local prior by high remote scores", score)
+	}
+	if score > 1.0 || score < 0.0 {
+		t.Errorf("aggregated score = %f, want within [0, 1]", score)
+	}
+}
+
+func TestEigenTrustStrategyAggregateWithNoObservationsReturnsPrior(t *testing.T) {
+	strategy := NewEigenTrustStrategy()
+	cfg := DefaultTrustConfig()
+	score := strategy.Aggregate(cfg, 0.42, nil)
+	if math.Abs(score-0.42) > 1e-9 {
+		t.Errorf("with no remote observations, score should equal local unchanged = %f, got %f", 0.42, score)
+	}
+}
+
+func TestRecordDimensionEventPopulatesDimensions(t *testing.T) {
+	tm := NewTrustManager(DefaultTrustConfig())
+	tm.RecordDimensionEvent("multi-dim-agent", "latency", true, 0.2)
+	tm.RecordDimensionEvent("multi-dim-agent", "correctness", false, 0.1)
+
+	score := tm.GetTrustScore("multi-dim-agent")
+	if _, ok := score.Dimensions["latency"]; !ok {
+		t.Error("expected latency dimension to be populated")
+	}
+	if _, ok := score.Dimensions["correctness"]; !ok {
+		t.Error("expected correctness dimension to be populated")
+	}
+}
+
+func TestWithTrustStrategyOnClient(t *testing.T) {
+	client, err := NewClient("beta-client", WithTrustStrategy(BetaReputationStrategy{}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.Trust.RecordSuccess(client.Identity.DID, 1)
+	score := client.Trust.GetTrustScore(client.Identity.DID)
+	if score.Overall <= 0 {
+		t.Error("expected a populated score under the Beta-reputation strategy")
+	}
+}
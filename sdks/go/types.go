@@ -70,9 +70,10 @@ type GovernanceResult struct {
 type Option func(*clientOptions)
 
 type clientOptions struct {
-	capabilities []string
-	trustConfig  *TrustConfig
-	policyRules  []PolicyRule
+	capabilities  []string
+	trustConfig   *TrustConfig
+	trustStrategy TrustStrategy
+	policyRules   []PolicyRule
 }
 
 // WithCapabilities sets capabilities on identity generation.
@@ -89,6 +90,14 @@ func WithTrustConfig(cfg TrustConfig) Option {
 	}
 }
 
+// WithTrustStrategy overrides the default linear reward/penalty trust
+// strategy, e.g. with BetaReputationStrategy or EigenTrustStrategy.
+func WithTrustStrategy(strategy TrustStrategy) Option {
+	return func(o *clientOptions) {
+		o.trustStrategy = strategy
+	}
+}
+
 // WithPolicyRules sets initial policy rules.
 func WithPolicyRules(rules []PolicyRule) Option {
 	return func(o *clientOptions) {